@@ -14,6 +14,63 @@ import (
 
 var collabLogger = utils.Logger.WithExtra("collab")
 
+// findCollabTarget looks for a team or user identified by a given name. Teams
+// are only considered for organization repositories. When neither is found,
+// the closest teams, members and collaborators are suggested.
+func findCollabTarget(repoURL *utils.RepoURL, name string, isOrg bool) (interface{}, error) {
+	if isOrg {
+		// At this point, we don't know whether the collaborator is a team or user
+		t, err := utils.GetTeamByName(repoURL.Username, name, collabLogger, false)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			return t, nil
+		}
+		collabLogger.Warn("No team found under %s/%s. Looking for users...", repoURL.Username, name)
+	}
+
+	url, err := octokit.UserURL.Expand(octokit.M{"user": name})
+	if err != nil {
+		return nil, err
+	}
+	u, resp := utils.NewClient().Users(url).One()
+	if resp.Response != nil && resp.Response.StatusCode != 404 {
+		utils.HandleClientError(resp, collabLogger)
+	}
+	if u != nil {
+		return u, nil
+	}
+
+	collabLogger.Warn("No user found with handle @%s.", name)
+	candidates := []string{}
+	if isOrg {
+		teams, err := utils.GetAllTeamsForOrg(repoURL.Username)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range teams {
+			candidates = append(candidates, t.Slug, t.Name)
+		}
+		members, err := utils.GetAllUsersForOrg(repoURL.Username)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			candidates = append(candidates, m.Login)
+		}
+	}
+	if collabs, err := utils.GetAllCollabs(repoURL); err == nil {
+		for _, c := range collabs {
+			candidates = append(candidates, c.Login)
+		}
+	}
+	if picked := utils.Suggest(name, candidates); picked != "" {
+		return findCollabTarget(repoURL, picked, isOrg)
+	}
+	return nil, fmt.Errorf("aborted")
+}
+
 var collabAdd = cli.Command{
 	Name:      "add",
 	Usage:     "Adds a user or team to a repository",
@@ -29,19 +86,6 @@ var collabAdd = cli.Command{
 			split := strings.Split(toAdd, ":")
 			role = split[1]
 			toAdd = split[0]
-
-			roleAliases := map[string]string{
-				"read":  "pull",
-				"write": "push",
-			}
-
-			if v, present := roleAliases[role]; present {
-				role = v
-			}
-
-			if role != "pull" && role != "push" && role != "admin" {
-				return fmt.Errorf("incorrect role %s: valid roles are pull/read, push/write and admin", role)
-			}
 		}
 
 		repoURL := utils.RepoURLFromString(c.Args()[0])
@@ -54,42 +98,23 @@ var collabAdd = cli.Command{
 			return fmt.Errorf("cannot set permission level on a non-org repository collaborator")
 		}
 
-		var target interface{}
-
-		if isOrg {
-			// At this point, we don't know whether the collaborator is a team or user
-			t, err := utils.GetTeamByName(repoURL.Username, toAdd, collabLogger, false)
-			if err != nil {
+		if role != "" {
+			var err error
+			if role, err = utils.ParsePermission(role, repoURL.Username); err != nil {
 				return err
 			}
-			if t != nil {
-				target = t
-			} else {
-				collabLogger.Warn("No team found under %s/%s. Looking for users...", repoURL.Username, toAdd)
-			}
 		}
 
-		client := utils.NewClient()
-		if target == nil {
-			url, err := octokit.UserURL.Expand(octokit.M{"user": toAdd})
-			if err != nil {
-				return err
-			}
-
-			u, resp := client.Users(url).One()
-			if resp.Response != nil && resp.Response.StatusCode != 404 {
-				utils.HandleClientError(resp, collabLogger)
-			}
-			if u == nil {
-				collabLogger.Warn("No user found with handle @%s. Aborting.", toAdd)
-				return fmt.Errorf("aborted")
-			}
-			if isOrg {
-				collabLogger.Warn("WARNING: Adding user to org repository as an outside collaborator!")
-			}
-			target = u
+		target, err := findCollabTarget(&repoURL, toAdd, isOrg)
+		if err != nil {
+			return err
+		}
+		if _, ok := target.(*octokit.User); ok && isOrg {
+			collabLogger.Warn("WARNING: Adding user to org repository as an outside collaborator!")
 		}
 
+		client := utils.NewClient()
+
 		if isOrg {
 			if t, ok := target.(*octokit.Team); ok {
 				collabLogger.Timing("Adding %s/%s to %s", repoURL.Username, t.Slug, repoURL.ToURL())
@@ -116,14 +141,11 @@ var collabAdd = cli.Command{
 				collabLogger.Warn("WARNING: Adding org user as an outside collaborator!")
 			} else {
 				fmt.Println("Hey there! You're about to add an outside user to an org repository.")
-				fmt.Printf("Mind checking out whether this is the @%s you're looking for?\n", toAdd)
+				fmt.Printf("Mind checking out whether this is the @%s you're looking for?\n", u.Login)
 				fmt.Println("")
-				fmt.Printf("        Name: %s\n", u.Name)
-				fmt.Printf("       Email: %s\n", u.Email)
-				fmt.Printf("Organization: %s\n", u.Company)
-				fmt.Printf("         URL: %s\n", u.Blog)
+				printUserProfile(u)
 				fmt.Println("")
-				if !utils.Confirm(fmt.Sprintf("Continue adding @%s? y/[n]", toAdd), false) {
+				if !utils.Confirm(fmt.Sprintf("Continue adding @%s? y/[n]", u.Login), false) {
 					return fmt.Errorf("aborting")
 				}
 			}
@@ -159,38 +181,12 @@ var collabRm = cli.Command{
 		collabLogger.Timing("Just a second...")
 		isOrg := utils.UserIsOrg(repoURL.Username)
 
-		var target interface{}
-
-		if isOrg {
-			// At this point, we don't know whether the collaborator is a team or user
-			t, err := utils.GetTeamByName(repoURL.Username, toRm, collabLogger, false)
-			if err != nil {
-				return err
-			}
-			if t != nil {
-				target = t
-			} else {
-				collabLogger.Warn("No team found under %s/%s. Looking for users...", repoURL.Username, toRm)
-			}
+		target, err := findCollabTarget(&repoURL, toRm, isOrg)
+		if err != nil {
+			return err
 		}
 
 		client := utils.NewClient()
-		if target == nil {
-			url, err := octokit.UserURL.Expand(octokit.M{"user": toRm})
-			if err != nil {
-				return err
-			}
-
-			u, resp := client.Users(url).One()
-			if resp.Response != nil && resp.Response.StatusCode != 404 {
-				utils.HandleClientError(resp, collabLogger)
-			}
-			if u == nil {
-				collabLogger.Warn("No user found with handle @%s. Aborting.", toRm)
-				return fmt.Errorf("aborted")
-			}
-			target = u
-		}
 
 		if isOrg {
 			if t, ok := target.(*octokit.Team); ok {
@@ -260,25 +256,7 @@ var collabList = cli.Command{
 				if len(collabs) > 0 {
 					fmt.Println("")
 					color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
-					table := tablewriter.NewWriter(os.Stdout)
-					table.SetHeader([]string{"User", "Push?", "Pull?", "Admin?"})
-					table.SetAutoFormatHeaders(true)
-					for _, collab := range collabs {
-						push := "No"
-						pull := "No"
-						admin := "No"
-						if collab.Permissions.Admin {
-							admin = "Yes"
-						}
-						if collab.Permissions.Pull {
-							pull = "Yes"
-						}
-						if collab.Permissions.Push {
-							push = "Yes"
-						}
-						table.Append([]string{fmt.Sprintf("@%s", collab.Login), push, pull, admin})
-					}
-					table.Render()
+					printCollaborators(collabs)
 				} else {
 					fmt.Println("No collaborators")
 				}
@@ -297,33 +275,237 @@ var collabList = cli.Command{
 			}
 			color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
 			if len(collabs) > 0 {
-				table := tablewriter.NewWriter(os.Stdout)
-				table.SetHeader([]string{"User", "Push?", "Pull?", "Admin?"})
-				table.SetAutoFormatHeaders(true)
-				for _, collab := range collabs {
-					push := "No"
-					pull := "No"
-					admin := "No"
-					if collab.Permissions.Admin {
-						admin = "Yes"
-					}
-					if collab.Permissions.Pull {
-						pull = "Yes"
+				printCollaborators(collabs)
+			} else {
+				fmt.Println("No collaborators")
+			}
+		}
+		return nil
+	},
+}
+
+var collabWho = cli.Command{
+	Name:      "who",
+	Usage:     "Explains the effective permission of a user on a repository",
+	ArgsUsage: "[repository] [username]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("Usage: gh collab who [repository] [username]")
+		}
+
+		username := strings.TrimPrefix(c.Args()[1], "@")
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		collabLogger.Timing("Just a second...")
+		perm, resp := utils.GetCollaboratorPermission(&repoURL, username)
+		utils.HandleClientError(resp, collabLogger)
+
+		sources := [][]string{}
+		if strings.EqualFold(repoURL.Username, username) {
+			sources = append(sources, []string{"Repository owner", "admin"})
+		}
+
+		collabLogger.Timing("Looking for direct grants...")
+		collabs, err := utils.GetCollabsByAffiliation(&repoURL, "direct")
+		if err != nil {
+			return err
+		}
+		for _, collab := range collabs {
+			if strings.EqualFold(collab.Login, username) {
+				sources = append(sources, []string{"Direct collaborator", collab.Role()})
+				break
+			}
+		}
+
+		if utils.UserIsOrg(repoURL.Username) {
+			collabLogger.Timing("Looking for team grants...")
+			repoTeams, err := utils.GetAllTeamsForRepo(&repoURL)
+			if err != nil {
+				return err
+			}
+			orgTeams, err := utils.GetAllNestedTeamsForOrg(repoURL.Username)
+			if err != nil {
+				return err
+			}
+			for _, team := range repoTeams {
+				isMember, err := utils.IsTeamMember(team.ID, username)
+				if err != nil {
+					return err
+				}
+				if !isMember {
+					continue
+				}
+				// Members of child teams inherit access from their parents. Find
+				// out which ones are actually responsible for this membership.
+				via := []string{}
+				for _, child := range utils.ChildTeams(orgTeams, team.ID) {
+					isMember, err := utils.IsTeamMember(child.ID, username)
+					if err != nil {
+						return err
 					}
-					if collab.Permissions.Push {
-						push = "Yes"
+					if isMember {
+						via = append(via, "@"+child.Slug)
 					}
-					table.Append([]string{fmt.Sprintf("@%s", collab.Login), push, pull, admin})
 				}
-				table.Render()
-			} else {
-				fmt.Println("No collaborators")
+				source := fmt.Sprintf("Team %s (@%s)", team.Name, team.Slug)
+				if len(via) > 0 {
+					source = fmt.Sprintf("%s via %s", source, strings.Join(via, ", "))
+				}
+				sources = append(sources, []string{source, team.Permission})
 			}
+
+			collabLogger.Timing("Looking for organization grants...")
+			membership, err := utils.GetOrgMembership(repoURL.Username, username)
+			if err != nil {
+				return err
+			}
+			if membership != nil && membership.State == "active" {
+				if membership.Role == "admin" {
+					sources = append(sources, []string{"Organization owner", "admin"})
+				}
+				org, err := utils.GetOrgDetails(repoURL.Username)
+				if err != nil {
+					return err
+				}
+				base := org.DefaultRepositoryPermission
+				if base == "" {
+					base = "unknown (visible to org owners only)"
+				}
+				sources = append(sources, []string{"Organization base permission", base})
+			}
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		effective := perm.Permission
+		if perm.RoleName != "" && perm.RoleName != perm.Permission {
+			effective = fmt.Sprintf("%s (%s)", perm.Permission, perm.RoleName)
+		}
+		fmt.Printf("@%s has %s access\n\n", username, effective)
+		if len(sources) > 0 {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Source", "Permission"})
+			table.SetAutoFormatHeaders(true)
+			table.AppendBulk(sources)
+			table.Render()
+		} else {
+			fmt.Println("No grants found")
 		}
 		return nil
 	},
 }
 
+var collabSetPermission = cli.Command{
+	Name:      "set-permission",
+	Usage:     "Changes the permission level of a user or team already granted access to a repository",
+	ArgsUsage: "[repository] [team-slug|contributor-username] [permission]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 3 {
+			return fmt.Errorf("Usage: gh collab set-permission [repository] [team-slug|contributor-username] [permission]")
+		}
+
+		target := strings.TrimPrefix(strings.ToLower(c.Args()[1]), "@")
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		collabLogger.Timing("Just a second...")
+		if !utils.UserIsOrg(repoURL.Username) {
+			return fmt.Errorf("cannot set permission level on a non-org repository collaborator")
+		}
+
+		permission, err := utils.ParsePermission(c.Args()[2], repoURL.Username)
+		if err != nil {
+			return err
+		}
+
+		client := utils.NewClient()
+		t, err := utils.GetTeamByName(repoURL.Username, target, collabLogger, false)
+		if err != nil {
+			return err
+		}
+		if t != nil {
+			teams, err := utils.GetAllTeamsForRepo(&repoURL)
+			if err != nil {
+				return err
+			}
+			hasAccess := false
+			for _, rt := range teams {
+				if rt.ID == t.ID {
+					hasAccess = true
+					break
+				}
+			}
+			if !hasAccess {
+				return fmt.Errorf("%s/%s has no access to %s. Use 'gh collab add' instead", repoURL.Username, t.Slug, repoURL.ToURL())
+			}
+			collabLogger.Timing("Updating %s/%s on %s", repoURL.Username, t.Slug, repoURL.ToURL())
+			success, resp := client.Teams().UpdateRepository(&octokit.TeamRepositoryURL, octokit.M{"id": t.ID, "owner": repoURL.Username, "repo": repoURL.RepoName}, permission)
+			if !success {
+				utils.HandleClientError(resp, collabLogger)
+			}
+			collabLogger.Success("%s/%s now has '%s' access to %s", repoURL.Username, t.Slug, permission, repoURL.ToURL())
+			return nil
+		}
+
+		collabs, err := utils.GetCollabsByAffiliation(&repoURL, "direct")
+		if err != nil {
+			return err
+		}
+		for _, collab := range collabs {
+			if strings.ToLower(collab.Login) != target {
+				continue
+			}
+			collabLogger.Timing("Updating @%s on %s", collab.Login, repoURL.ToURL())
+			success, resp := client.Collaborators().AddCollaborator(&octokit.CollaboratorsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "username": collab.Login}, permission)
+			if !success {
+				utils.HandleClientError(resp, collabLogger)
+			}
+			collabLogger.Success("@%s now has '%s' access to %s", collab.Login, permission, repoURL.ToURL())
+			return nil
+		}
+
+		// Users who did not accept their invitation yet are not listed as
+		// collaborators, but their invitations can be updated as well.
+		invitations, err := utils.GetAllInvitationsForRepo(&repoURL)
+		if err != nil {
+			return err
+		}
+		for _, inv := range invitations {
+			if inv.Invitee == nil || strings.ToLower(inv.Invitee.Login) != target {
+				continue
+			}
+			collabLogger.Timing("Updating invitation for @%s on %s", inv.Invitee.Login, repoURL.ToURL())
+			resp := utils.APIRequest("PATCH", &utils.RepositoryInvitationsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "id": inv.ID}, octokit.M{"permissions": utils.InvitationPermission(permission)}, nil)
+			utils.HandleClientError(resp, collabLogger)
+			collabLogger.Success("Invitation for @%s on %s now grants '%s'", inv.Invitee.Login, repoURL.ToURL(), permission)
+			return nil
+		}
+
+		return fmt.Errorf("%s is neither a team nor a collaborator on %s. Use 'gh collab add' instead", target, repoURL.ToURL())
+	},
+}
+
+func printCollaborators(collabs []utils.Collaborator) {
+	yesNo := func(v bool) string {
+		if v {
+			return "Yes"
+		}
+		return "No"
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"User", "Role", "Pull?", "Triage?", "Push?", "Maintain?", "Admin?"})
+	table.SetAutoFormatHeaders(true)
+	for _, collab := range collabs {
+		p := collab.Permissions
+		if p == nil {
+			p = &utils.RepoPermissions{}
+		}
+		table.Append([]string{fmt.Sprintf("@%s", collab.Login), collab.Role(), yesNo(p.Pull), yesNo(p.Triage), yesNo(p.Push), yesNo(p.Maintain), yesNo(p.Admin)})
+	}
+	table.Render()
+}
+
 // Collab provides collaboration-related commands
 var Collab = cli.Command{
 	Name:    "collab",
@@ -333,5 +515,9 @@ var Collab = cli.Command{
 		collabAdd,
 		collabRm,
 		collabList,
+		collabWho,
+		collabInvites,
+		collabSetPermission,
+		collabCopy,
 	},
 }
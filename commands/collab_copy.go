@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+// accessGrant represents a single team or user grant on a repository
+type accessGrant struct {
+	team         *octokit.Team
+	login        string
+	permission   string
+	invitationID int
+}
+
+func (g accessGrant) String() string {
+	if g.team != nil {
+		return fmt.Sprintf("Team %s (@%s)", g.team.Name, g.team.Slug)
+	}
+	return "@" + g.login
+}
+
+func (g accessGrant) key() string {
+	if g.team != nil {
+		return "team:" + strings.ToLower(g.team.Slug)
+	}
+	return "user:" + strings.ToLower(g.login)
+}
+
+// sameAccess compares permission levels regardless of their naming scheme
+func sameAccess(a, b string) bool {
+	if utils.PermissionRank(a) != 0 || utils.PermissionRank(b) != 0 {
+		return utils.PermissionRank(a) == utils.PermissionRank(b)
+	}
+	return strings.EqualFold(a, b)
+}
+
+// repoGrants returns team and direct user grants of a given repository,
+// respecting the provided filters
+func repoGrants(repoURL *utils.RepoURL, isOrg, teams, users bool) ([]accessGrant, error) {
+	grants := []accessGrant{}
+	if teams && isOrg {
+		repoTeams, err := utils.GetAllTeamsForRepo(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range repoTeams {
+			t := t
+			grants = append(grants, accessGrant{team: &t, permission: t.Permission})
+		}
+	}
+	if users {
+		collabs, err := utils.GetCollabsByAffiliation(repoURL, "direct")
+		if err != nil {
+			return nil, err
+		}
+		for _, collab := range collabs {
+			if strings.EqualFold(collab.Login, repoURL.Username) {
+				continue
+			}
+			grants = append(grants, accessGrant{login: collab.Login, permission: collab.Role()})
+		}
+		if !isOrg {
+			// Invitations are the only way to add collaborators on user
+			// repositories, so pending ones must be considered as well.
+			invitations, err := utils.GetAllInvitationsForRepo(repoURL)
+			if err != nil {
+				return nil, err
+			}
+			for _, inv := range invitations {
+				if inv.Invitee != nil {
+					grants = append(grants, accessGrant{login: inv.Invitee.Login, permission: inv.Permissions, invitationID: inv.ID})
+				}
+			}
+		}
+	}
+	return grants, nil
+}
+
+var collabCopy = cli.Command{
+	Name:      "copy",
+	Usage:     "Reproduces teams and collaborators of a repository on another one",
+	ArgsUsage: "(--teams-only|--users-only) (--replace) [source-repository] [destination-repository]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "teams-only",
+			Usage: "only copies team grants",
+		},
+		cli.BoolFlag{
+			Name:  "users-only",
+			Usage: "only copies collaborator grants",
+		},
+		cli.BoolFlag{
+			Name:  "replace",
+			Usage: "removes grants present on the destination that are absent on the source",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("Usage: gh collab copy (--teams-only|--users-only) (--replace) [source-repository] [destination-repository]")
+		}
+		if c.Bool("teams-only") && c.Bool("users-only") {
+			return fmt.Errorf("--teams-only and --users-only are mutually exclusive")
+		}
+		copyTeams := !c.Bool("users-only")
+		copyUsers := !c.Bool("teams-only")
+
+		src := utils.RepoURLFromString(c.Args()[0])
+		src.AutoComplete()
+		dst := utils.RepoURLFromString(c.Args()[1])
+		dst.AutoComplete()
+
+		collabLogger.Timing("Just a second...")
+		srcIsOrg := utils.UserIsOrg(src.Username)
+		dstIsOrg := srcIsOrg
+		if !strings.EqualFold(src.Username, dst.Username) {
+			dstIsOrg = utils.UserIsOrg(dst.Username)
+		}
+		if copyTeams && srcIsOrg && !dstIsOrg {
+			if !copyUsers {
+				return fmt.Errorf("%s is not an organization repository and cannot have teams", dst.ToURL())
+			}
+			collabLogger.Warn("%s is not an organization repository. Skipping teams...", dst.ToURL())
+			copyTeams = false
+		}
+
+		collabLogger.Timing("Reading grants from %s", src.ToURL())
+		srcGrants, err := repoGrants(&src, srcIsOrg, copyTeams, copyUsers)
+		if err != nil {
+			return err
+		}
+		collabLogger.Timing("Reading grants from %s", dst.ToURL())
+		dstGrants, err := repoGrants(&dst, dstIsOrg, copyTeams, copyUsers)
+		if err != nil {
+			return err
+		}
+
+		current := map[string]accessGrant{}
+		for _, g := range dstGrants {
+			current[g.key()] = g
+		}
+		wanted := map[string]bool{}
+
+		type change struct {
+			action string
+			grant  accessGrant
+		}
+		changes := []change{}
+		for _, g := range srcGrants {
+			wanted[g.key()] = true
+			if g.team != nil && !strings.EqualFold(src.Username, dst.Username) {
+				// Teams belong to an organization; look for one under the
+				// same slug on the destination.
+				t, err := utils.GetTeamByName(dst.Username, g.team.Slug, collabLogger, false)
+				if err != nil {
+					return err
+				}
+				if t == nil {
+					collabLogger.Warn("No team named @%s exists on %s. Skipping...", g.team.Slug, dst.Username)
+					continue
+				}
+				g.team = t
+			}
+			if !dstIsOrg {
+				// Permission levels cannot be set on user repositories.
+				g.permission = ""
+			}
+			cur, present := current[g.key()]
+			if !present {
+				changes = append(changes, change{"+", g})
+			} else if g.permission != "" && !sameAccess(cur.permission, g.permission) {
+				changes = append(changes, change{"~", g})
+			}
+		}
+		if c.Bool("replace") {
+			for _, g := range dstGrants {
+				if !wanted[g.key()] {
+					changes = append(changes, change{"-", g})
+				}
+			}
+		}
+
+		if len(changes) == 0 {
+			collabLogger.Success("%s already has the same access as %s", dst.ToURL(), src.ToURL())
+			return nil
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Printf("%s → %s\n", src.ToURL(), dst.ToURL())
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"", "Grant", "Permission"})
+		table.SetAutoFormatHeaders(true)
+		for _, ch := range changes {
+			permission := ch.grant.permission
+			if cur, present := current[ch.grant.key()]; present && ch.action == "~" {
+				permission = fmt.Sprintf("%s → %s", cur.permission, permission)
+			}
+			table.Append([]string{ch.action, ch.grant.String(), permission})
+		}
+		table.Render()
+		fmt.Println("")
+		if !utils.Confirm(fmt.Sprintf("Apply %d change(s) to %s? y/[n]", len(changes), dst.ToURL()), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		failed := []string{}
+		for _, ch := range changes {
+			g := ch.grant
+			permission := g.permission
+			if permission != "" && ch.action != "-" {
+				if permission, err = utils.ParsePermission(permission, dst.Username); err != nil {
+					return err
+				}
+			}
+			collabLogger.Timing("Updating %s on %s", g, dst.ToURL())
+			var resp *octokit.Result
+			switch {
+			case g.team != nil && ch.action == "-":
+				resp = utils.RemoveTeamRepo(g.team.ID, &dst)
+			case g.team != nil:
+				resp = utils.SetTeamRepoPermission(g.team.ID, &dst, permission)
+			case g.invitationID != 0 && ch.action == "-":
+				resp = utils.APIRequest("DELETE", &utils.RepositoryInvitationsURL, octokit.M{"owner": dst.Username, "repo": dst.RepoName, "id": g.invitationID}, nil, nil)
+			case ch.action == "-":
+				resp = utils.RemoveCollaborator(&dst, g.login)
+			default:
+				resp = utils.SetCollaboratorPermission(&dst, g.login, permission)
+			}
+			if resp.HasError() {
+				collabLogger.Warn("Could not update %s on %s: %s", g, dst.ToURL(), utils.ResultError(resp))
+				failed = append(failed, g.String())
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("%d of %d change(s) could not be applied to %s: %s", len(failed), len(changes), dst.ToURL(), strings.Join(failed, ", "))
+		}
+		collabLogger.Success("Copied access from %s to %s", src.ToURL(), dst.ToURL())
+		return nil
+	},
+}
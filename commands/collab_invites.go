@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+func collabInvitesListAction(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		return fmt.Errorf("usage: gh collab invites list [repository]")
+	}
+	repoURL := utils.RepoURLFromString(c.Args()[0])
+	repoURL.AutoComplete()
+
+	collabLogger.Timing("Fetching invitations for %s", repoURL.ToURL())
+	invitations, err := utils.GetAllInvitationsForRepo(&repoURL)
+	if err != nil {
+		if err, ok := err.(*octokit.ResponseError); ok {
+			collabLogger.Error("%s", utils.FormatError(err))
+			os.Exit(1)
+		}
+		return err
+	}
+
+	fmt.Println("")
+	color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+	if len(invitations) == 0 {
+		fmt.Println("No pending invitations")
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Invitee", "Inviter", "Permission", "Created", "Expired?"})
+	table.SetAutoFormatHeaders(true)
+	for _, inv := range invitations {
+		table.Append(invitationRow(inv))
+	}
+	table.Render()
+	return nil
+}
+
+func invitationRow(inv utils.RepositoryInvitation) []string {
+	invitee := ""
+	inviter := ""
+	created := ""
+	expired := "No"
+	if inv.Invitee != nil {
+		invitee = "@" + inv.Invitee.Login
+	}
+	if inv.Inviter != nil {
+		inviter = "@" + inv.Inviter.Login
+	}
+	if inv.CreatedAt != nil {
+		created = inv.CreatedAt.Format("2006-01-02")
+	}
+	if inv.Expired {
+		expired = "Yes"
+	}
+	return []string{strconv.Itoa(inv.ID), invitee, inviter, inv.Permissions, created, expired}
+}
+
+// invitationLabel describes an invitation by its ID and invitee. Invitees
+// are absent for invitations whose accounts were since deleted.
+func invitationLabel(inv *utils.RepositoryInvitation) string {
+	if inv.Invitee == nil {
+		return fmt.Sprintf("invitation #%d", inv.ID)
+	}
+	return fmt.Sprintf("invitation #%d for @%s", inv.ID, inv.Invitee.Login)
+}
+
+// findRepoInvitation looks for a pending invitation on a repository by its ID
+// or by the invitee's login
+func findRepoInvitation(repoURL *utils.RepoURL, needle string) (*utils.RepositoryInvitation, error) {
+	invitations, err := utils.GetAllInvitationsForRepo(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	needle = strings.TrimPrefix(needle, "@")
+	for _, inv := range invitations {
+		if strconv.Itoa(inv.ID) == needle || (inv.Invitee != nil && strings.EqualFold(inv.Invitee.Login, needle)) {
+			inv := inv
+			return &inv, nil
+		}
+	}
+	return nil, fmt.Errorf("no pending invitation matching '%s' was found for %s", needle, repoURL.ToURL())
+}
+
+var collabInvitesList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists pending invitations for a repository",
+	ArgsUsage: "[repository]",
+	Action:    collabInvitesListAction,
+}
+
+var collabInvitesCancel = cli.Command{
+	Name:      "cancel",
+	Usage:     "Cancels a pending invitation",
+	ArgsUsage: "[repository] [invitation-id|invitee-username]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh collab invites cancel [repository] [invitation-id|invitee-username]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		collabLogger.Timing("Just a second...")
+		inv, err := findRepoInvitation(&repoURL, c.Args()[1])
+		if err != nil {
+			return err
+		}
+
+		if !utils.Confirm(fmt.Sprintf("Cancel %s on %s? y/[n]", invitationLabel(inv), repoURL.ToURL()), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		collabLogger.Timing("Cancelling invitation #%d", inv.ID)
+		resp := utils.APIRequest("DELETE", &utils.RepositoryInvitationsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "id": inv.ID}, nil, nil)
+		utils.HandleClientError(resp, collabLogger)
+		collabLogger.Success("Cancelled %s on %s", invitationLabel(inv), repoURL.ToURL())
+		return nil
+	},
+}
+
+var collabInvitesUpdate = cli.Command{
+	Name:      "update",
+	Usage:     "Changes the permission level of a pending invitation",
+	ArgsUsage: "[repository] [invitation-id|invitee-username] [permission]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 3 {
+			return fmt.Errorf("usage: gh collab invites update [repository] [invitation-id|invitee-username] [permission]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		permission, err := utils.ParsePermission(c.Args()[2], "")
+		if err != nil {
+			return err
+		}
+		permission = utils.InvitationPermission(permission)
+
+		collabLogger.Timing("Just a second...")
+		inv, err := findRepoInvitation(&repoURL, c.Args()[1])
+		if err != nil {
+			return err
+		}
+
+		collabLogger.Timing("Updating invitation #%d", inv.ID)
+		resp := utils.APIRequest("PATCH", &utils.RepositoryInvitationsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "id": inv.ID}, octokit.M{"permissions": permission}, nil)
+		utils.HandleClientError(resp, collabLogger)
+		collabLogger.Success("Updated %s on %s to grant '%s'", invitationLabel(inv), repoURL.ToURL(), permission)
+		return nil
+	},
+}
+
+var collabInvites = cli.Command{
+	Name:      "invites",
+	Usage:     "Manages pending invitations for a repository",
+	ArgsUsage: "[repository]",
+	Action:    collabInvitesListAction,
+	Subcommands: []cli.Command{
+		collabInvitesList,
+		collabInvitesCancel,
+		collabInvitesUpdate,
+	},
+}
@@ -0,0 +1,416 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var deployKeyLogger = utils.Logger.WithExtra("deploy-key")
+
+func handleDeployKeyError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		deployKeyLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+func deployKeyAccess(k *utils.DeployKey) string {
+	if k.ReadOnly {
+		return "read-only"
+	}
+	return "read-write"
+}
+
+func deployKeyFingerprint(k *utils.DeployKey) string {
+	key, err := utils.ParseSSHPublicKey(k.Key)
+	if err != nil {
+		return ""
+	}
+	return key.Fingerprint()
+}
+
+// reposMatching returns repositories of an organization or of the
+// authenticated user whose names match a given glob pattern
+func reposMatching(owner, pattern string) ([]octokit.Repository, error) {
+	var all []octokit.Repository
+	var err error
+	if utils.UserIsOrg(owner) {
+		all, err = utils.GetAllReposForOrg(owner)
+	} else {
+		url, uerr := octokit.CurrentUserURL.Expand(nil)
+		if uerr != nil {
+			return nil, uerr
+		}
+		me, resp := utils.NewClient().Users(url).One()
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		if !strings.EqualFold(me.Login, owner) {
+			return nil, fmt.Errorf("%s is neither an organization nor you. Deploy keys can only be rotated on repositories you administer", owner)
+		}
+		all, err = utils.GetAllUserRepositories()
+	}
+	if err != nil {
+		return nil, err
+	}
+	repos := []octokit.Repository{}
+	for _, r := range all {
+		if !strings.EqualFold(r.Owner.Login, owner) {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, r.Name); ok {
+			repos = append(repos, r)
+		}
+	}
+	return repos, nil
+}
+
+var deployKeyList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists deploy keys of a repository",
+	ArgsUsage: "[repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh deploy-key list [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		deployKeyLogger.Timing("One moment, please...")
+		keys, err := utils.GetDeployKeys(&repoURL)
+		if err != nil {
+			return handleDeployKeyError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		if len(keys) == 0 {
+			fmt.Println("No deploy keys")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Title", "Access", "Fingerprint", "Added"})
+		table.SetAutoFormatHeaders(true)
+		for _, k := range keys {
+			added := ""
+			if k.CreatedAt != nil {
+				added = k.CreatedAt.Format("2006-01-02")
+			}
+			table.Append([]string{strconv.Itoa(k.ID), k.Title, deployKeyAccess(&k), deployKeyFingerprint(&k), added})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var deployKeyAdd = cli.Command{
+	Name:      "add",
+	Usage:     "Adds a deploy key to a repository, optionally generating it",
+	ArgsUsage: "(--title TITLE) (--read-write) (--generate FILE) [repository] ([file])",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "title",
+			Usage: "title of the key. Defaults to the key's comment, or the hostname",
+		},
+		cli.BoolFlag{
+			Name:  "read-write",
+			Usage: "allows the key to push to the repository. Keys are read-only by default",
+		},
+		cli.StringFlag{
+			Name:  "generate",
+			Usage: "generates a new ed25519 key pair, writing the private key to FILE and the public key to FILE.pub",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		generate := c.String("generate")
+		if len(c.Args()) < 1 || len(c.Args()) > 2 || (generate == "") == (len(c.Args()) == 1) {
+			return fmt.Errorf("usage: gh deploy-key add (--title TITLE) (--read-write) (--generate FILE) [repository] ([file])")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		var key *utils.SSHPublicKey
+		var err error
+		if generate != "" {
+			deployKeyLogger.Timing("Generating %s", generate)
+			key, err = utils.GenerateSSHKeyPair(generate, "deploy@"+repoURL.ToURL())
+		} else {
+			key, err = utils.ReadSSHPublicKey(c.Args()[1])
+		}
+		if err != nil {
+			return err
+		}
+		title := c.String("title")
+		if title == "" {
+			title = key.Comment
+		}
+		if title == "" {
+			if title, err = os.Hostname(); err != nil {
+				return fmt.Errorf("please provide a title using --title")
+			}
+		}
+
+		deployKeyLogger.Timing("One moment, please...")
+		keys, err := utils.GetDeployKeys(&repoURL)
+		if err != nil {
+			return handleDeployKeyError(err)
+		}
+		for _, k := range keys {
+			if registered, err := utils.ParseSSHPublicKey(k.Key); err == nil && registered.Equal(key) {
+				deployKeyLogger.Info("This key is already a deploy key of %s as %s (%d)", repoURL.ToURL(), k.Title, k.ID)
+				return nil
+			}
+		}
+
+		created, resp := utils.AddDeployKey(&repoURL, title, key, c.Bool("read-write"))
+		utils.HandleClientError(resp, deployKeyLogger)
+		deployKeyLogger.Success("Added %s deploy key %s (%d) to %s: %s", deployKeyAccess(created), created.Title, created.ID, repoURL.ToURL(), key.Fingerprint())
+		return nil
+	},
+}
+
+var deployKeyRm = cli.Command{
+	Name:      "rm",
+	Aliases:   []string{"delete"},
+	Usage:     "Removes one or more deploy keys from a repository",
+	ArgsUsage: "[repository] [id|title...]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 2 {
+			return fmt.Errorf("usage: gh deploy-key rm [repository] [id|title...]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		deployKeyLogger.Timing("One moment, please...")
+		keys, err := utils.GetDeployKeys(&repoURL)
+		if err != nil {
+			return handleDeployKeyError(err)
+		}
+		toRemove := []utils.DeployKey{}
+		for _, arg := range c.Args()[1:] {
+			found := false
+			for _, k := range keys {
+				if strconv.Itoa(k.ID) == arg || k.Title == arg {
+					toRemove = append(toRemove, k)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("could not find a deploy key identified by '%s' on %s", arg, repoURL.ToURL())
+			}
+		}
+
+		titles := []string{}
+		for _, k := range toRemove {
+			titles = append(titles, fmt.Sprintf("%s (%d)", k.Title, k.ID))
+		}
+		if !utils.Confirm(fmt.Sprintf("Remove %s from %s? y/[n]", strings.Join(titles, ", "), repoURL.ToURL()), false) {
+			return fmt.Errorf("aborting")
+		}
+		for _, k := range toRemove {
+			deployKeyLogger.Timing("Removing %s (%d)", k.Title, k.ID)
+			resp := utils.APIRequest("DELETE", &utils.DeployKeysURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "id": k.ID}, nil, nil)
+			utils.HandleClientError(resp, deployKeyLogger)
+		}
+		deployKeyLogger.Success("Removed %d deploy key(s) from %s", len(toRemove), repoURL.ToURL())
+		return nil
+	},
+}
+
+// deployKeyRotation represents a deploy key to be replaced on a repository.
+// Key pairs left behind by an interrupted rotation are reused, and keys
+// already added from them are not added again.
+type deployKeyRotation struct {
+	repo      utils.RepoURL
+	path      string
+	readWrite bool
+	old       []utils.DeployKey
+	key       *utils.SSHPublicKey
+	added     bool
+}
+
+var deployKeyRotate = cli.Command{
+	Name:      "rotate",
+	Usage:     "Replaces deploy keys of repositories matching a pattern with freshly generated ones",
+	ArgsUsage: "(--title TITLE) (--read-write) (--dir DIRECTORY) (--dry-run) [owner] [pattern]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "title",
+			Usage: "title of the keys being rotated",
+			Value: "deploy",
+		},
+		cli.BoolFlag{
+			Name:  "read-write",
+			Usage: "allows new keys to push, on repositories without a key to replace",
+		},
+		cli.StringFlag{
+			Name:  "dir",
+			Usage: "directory to write generated key pairs into",
+			Value: ".",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only shows changes, without applying them",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh deploy-key rotate (--title TITLE) (--read-write) (--dir DIRECTORY) (--dry-run) [owner] [pattern]")
+		}
+		owner, pattern := c.Args()[0], c.Args()[1]
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern '%s'", pattern)
+		}
+		title := c.String("title")
+
+		deployKeyLogger.Timing("One moment, please...")
+		repos, err := reposMatching(owner, pattern)
+		if err != nil {
+			return handleDeployKeyError(err)
+		}
+		if len(repos) == 0 {
+			return fmt.Errorf("no repositories of %s match '%s'", owner, pattern)
+		}
+
+		rotations := []deployKeyRotation{}
+		for _, repo := range repos {
+			r := utils.RepoURL{Username: repo.Owner.Login, RepoName: repo.Name}
+			deployKeyLogger.Timing("Checking %s...", repo.FullName)
+			keys, err := utils.GetDeployKeys(&r)
+			if err != nil {
+				return handleDeployKeyError(err)
+			}
+			rotation := deployKeyRotation{
+				repo:      r,
+				path:      filepath.Join(c.String("dir"), repo.Name+"-"+title),
+				readWrite: c.Bool("read-write"),
+			}
+			_, privErr := os.Stat(rotation.path)
+			_, pubErr := os.Stat(rotation.path + ".pub")
+			if privErr == nil && pubErr == nil {
+				if rotation.key, err = utils.ReadSSHPublicKey(rotation.path + ".pub"); err != nil {
+					return err
+				}
+			} else if privErr == nil || pubErr == nil {
+				return fmt.Errorf("only one half of the key pair at %s exists. Please move it away or use --dir", rotation.path)
+			}
+			for _, k := range keys {
+				if k.Title != title {
+					continue
+				}
+				if registered, err := utils.ParseSSHPublicKey(k.Key); err == nil && rotation.key != nil && registered.Equal(rotation.key) {
+					rotation.added = true
+					continue
+				}
+				rotation.old = append(rotation.old, k)
+				// New keys keep the access of those they replace
+				rotation.readWrite = !k.ReadOnly
+			}
+			if rotation.added && len(rotation.old) == 0 {
+				deployKeyLogger.Info("%s was already rotated using %s", repo.FullName, rotation.path)
+				continue
+			}
+			rotations = append(rotations, rotation)
+		}
+		if len(rotations) == 0 {
+			deployKeyLogger.Success("All deploy keys were already rotated")
+			return nil
+		}
+
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Repository", "Access", "Replaces", "Private key"})
+		table.SetAutoFormatHeaders(true)
+		for _, rot := range rotations {
+			access := "read-only"
+			if rot.readWrite {
+				access = "read-write"
+			}
+			replaces := []string{}
+			for _, k := range rot.old {
+				replaces = append(replaces, strconv.Itoa(k.ID))
+			}
+			path := rot.path
+			if rot.key != nil {
+				path += " (existing)"
+			}
+			table.Append([]string{rot.repo.ToURL(), access, strings.Join(replaces, ", "), path})
+		}
+		table.Render()
+		fmt.Println("")
+
+		if c.Bool("dry-run") {
+			deployKeyLogger.Info("Dry run: %d key(s) were not rotated", len(rotations))
+			return nil
+		}
+		if !utils.Confirm(fmt.Sprintf("Generate and add %d key(s) titled %s, removing those they replace? y/[n]", len(rotations), title), false) {
+			return fmt.Errorf("aborting")
+		}
+		if err := os.MkdirAll(c.String("dir"), 0700); err != nil {
+			return err
+		}
+
+		// New keys are added before old ones are removed, so repositories are
+		// never left without a working key.
+		failed := []string{}
+		for _, rot := range rotations {
+			deployKeyLogger.Timing("Rotating %s", rot.repo.ToURL())
+			if err := rotateDeployKey(&rot, title); err != nil {
+				deployKeyLogger.Warn("Could not rotate %s: %s", rot.repo.ToURL(), err)
+				failed = append(failed, rot.repo.ToURL())
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("could not rotate deploy keys of %s. Run the command again to resume: generated key pairs will be reused", strings.Join(failed, ", "))
+		}
+		deployKeyLogger.Success("Rotated deploy keys of %d repositories. Private keys were written to %s", len(rotations), c.String("dir"))
+		return nil
+	},
+}
+
+// rotateDeployKey generates and adds the new key of a rotation, unless done
+// by a previous run, then removes the keys it replaces
+func rotateDeployKey(rot *deployKeyRotation, title string) error {
+	key := rot.key
+	if key == nil {
+		var err error
+		if key, err = utils.GenerateSSHKeyPair(rot.path, "deploy@"+rot.repo.ToURL()); err != nil {
+			return err
+		}
+	}
+	if !rot.added {
+		if _, resp := utils.AddDeployKey(&rot.repo, title, key, rot.readWrite); resp.HasError() {
+			return fmt.Errorf("%s", utils.ResultError(resp))
+		}
+	}
+	for _, k := range rot.old {
+		resp := utils.APIRequest("DELETE", &utils.DeployKeysURL, octokit.M{"owner": rot.repo.Username, "repo": rot.repo.RepoName, "id": k.ID}, nil, nil)
+		if resp.HasError() && !utils.IsNotFound(resp) {
+			return fmt.Errorf("%s", utils.ResultError(resp))
+		}
+	}
+	return nil
+}
+
+// DeployKey exposes commands managing deploy keys of repositories
+var DeployKey = cli.Command{
+	Name:    "deploy-key",
+	Aliases: []string{"deploy-keys"},
+	Usage:   "Manages deploy keys of repositories",
+	Subcommands: []cli.Command{
+		deployKeyList,
+		deployKeyAdd,
+		deployKeyRm,
+		deployKeyRotate,
+	},
+}
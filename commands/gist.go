@@ -0,0 +1,514 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var gistLogger = utils.Logger.WithExtra("gist")
+
+func handleGistError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		gistLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+func gistVisibility(g *octokit.Gist) string {
+	if g.Public {
+		return "public"
+	}
+	return "secret"
+}
+
+// gistTitle returns a gist's description, falling back to the name of its
+// first file
+func gistTitle(g *octokit.Gist) string {
+	if d := strings.TrimSpace(g.Description); d != "" {
+		return d
+	}
+	if names := utils.GistFileNames(g); len(names) > 0 {
+		return names[0]
+	}
+	return g.ID
+}
+
+// gistFileName ensures a gist has a file with a given name, suggesting close
+// matches otherwise
+func gistFileName(g *octokit.Gist, name string) (string, error) {
+	if _, ok := g.Files[name]; ok {
+		return name, nil
+	}
+	gistLogger.Warn("Gist %s has no file named %s", g.ID, name)
+	if match := utils.Suggest(name, utils.GistFileNames(g)); match != "" {
+		return match, nil
+	}
+	return "", fmt.Errorf("aborting")
+}
+
+// gistIDs parses one or more gist IDs or URLs
+func gistIDs(args []string) []string {
+	ids := []string{}
+	for _, arg := range args {
+		ids = append(ids, utils.GistID(arg))
+	}
+	return ids
+}
+
+var gistCreate = cli.Command{
+	Name:      "create",
+	Usage:     "Creates a gist from one or more files, or the standard input",
+	ArgsUsage: "(--public) (--desc TEXT) (--filename NAME) [file...]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "public",
+			Usage: "creates a public gist. Gists are secret by default",
+		},
+		cli.StringFlag{
+			Name:  "desc",
+			Usage: "description of the gist",
+		},
+		cli.StringFlag{
+			Name:  "filename",
+			Usage: "name of the file read from the standard input",
+			Value: "gistfile1.txt",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		files := map[string]octokit.M{}
+		paths := []string(c.Args())
+		if len(paths) == 0 {
+			paths = []string{"-"}
+		}
+		for _, path := range paths {
+			var data []byte
+			var err error
+			name := filepath.Base(path)
+			if path == "-" {
+				name = c.String("filename")
+				if utils.IsInteractive() {
+					var text string
+					text, err = utils.EditText("")
+					data = []byte(text)
+				} else {
+					data, err = ioutil.ReadAll(os.Stdin)
+				}
+			} else {
+				data, err = ioutil.ReadFile(path)
+			}
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(string(data)) == "" {
+				return fmt.Errorf("aborting due to an empty file: %s", name)
+			}
+			if _, ok := files[name]; ok {
+				return fmt.Errorf("more than one file is named %s", name)
+			}
+			files[name] = octokit.M{"content": string(data)}
+		}
+
+		gistLogger.Timing("One moment, please...")
+		params := octokit.M{
+			"description": c.String("desc"),
+			"public":      c.Bool("public"),
+			"files":       files,
+		}
+		gist, resp := utils.NewClient().Gists().Create(&octokit.GistsURL, nil, params)
+		utils.HandleClientError(resp, gistLogger)
+		gistLogger.Success("Created %s gist with %d file(s): %s", gistVisibility(gist), len(files), gist.HtmlURL)
+		return nil
+	},
+}
+
+var gistList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists gists of the authenticated user, or of a given user",
+	ArgsUsage: "(--public|--secret) (--starred) (--limit N) ([username])",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "public",
+			Usage: "only lists public gists",
+		},
+		cli.BoolFlag{
+			Name:  "secret",
+			Usage: "only lists secret gists",
+		},
+		cli.BoolFlag{
+			Name:  "starred",
+			Usage: "lists gists starred by the authenticated user",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "maximum number of gists to list. Use 0 to list all of them",
+			Value: 30,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) > 1 || (c.Bool("public") && c.Bool("secret")) || (c.Bool("starred") && len(c.Args()) == 1) {
+			return fmt.Errorf("usage: gh gist list (--public|--secret) (--starred) (--limit N) ([username])")
+		}
+
+		url, params, title := &octokit.GistsURL, octokit.M{}, "Your gists"
+		if c.Bool("starred") {
+			url, title = &octokit.GistsStarredURL, "Starred gists"
+		} else if len(c.Args()) == 1 {
+			username := strings.TrimPrefix(c.Args()[0], "@")
+			url, params, title = &octokit.GistsUserURL, octokit.M{"username": username}, "Gists of @"+username
+		}
+
+		gistLogger.Timing("One moment, please...")
+		// Visibility is filtered locally, so the limit is only applied
+		// afterwards.
+		all, err := utils.GetGists(url, params, 0)
+		if err != nil {
+			return handleGistError(err)
+		}
+		gists := []octokit.Gist{}
+		for _, g := range all {
+			if (c.Bool("public") && !g.Public) || (c.Bool("secret") && g.Public) {
+				continue
+			}
+			gists = append(gists, g)
+			if c.Int("limit") > 0 && len(gists) == c.Int("limit") {
+				break
+			}
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(title)
+		if len(gists) == 0 {
+			fmt.Println("No gists")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Description", "Files", "Visibility", "Updated"})
+		table.SetAutoFormatHeaders(true)
+		for _, g := range gists {
+			updated := ""
+			if g.UpdatedAt != nil {
+				updated = g.UpdatedAt.Format("2006-01-02")
+			}
+			table.Append([]string{g.ID, gistTitle(&g), strconv.Itoa(len(g.Files)), gistVisibility(&g), updated})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var gistView = cli.Command{
+	Name:      "view",
+	Usage:     "Shows the contents of a gist",
+	ArgsUsage: "(--raw) (--file NAME) (--revision SHA) [id]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "raw",
+			Usage: "only prints file contents, suitable for piping",
+		},
+		cli.StringFlag{
+			Name:  "file",
+			Usage: "only shows a given file of the gist",
+		},
+		cli.StringFlag{
+			Name:  "revision",
+			Usage: "shows the gist as of a given revision",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh gist view (--raw) (--file NAME) (--revision SHA) [id]")
+		}
+
+		if !c.Bool("raw") {
+			gistLogger.Timing("One moment, please...")
+		}
+		gist, resp := utils.GetGist(utils.GistID(c.Args()[0]), c.String("revision"))
+		utils.HandleClientError(resp, gistLogger)
+
+		names := utils.GistFileNames(gist)
+		if file := c.String("file"); file != "" {
+			name, err := gistFileName(gist, file)
+			if err != nil {
+				return err
+			}
+			names = []string{name}
+		}
+
+		if c.Bool("raw") {
+			for _, name := range names {
+				content, err := utils.GistFileContent(gist.Files[name])
+				if err != nil {
+					return err
+				}
+				fmt.Print(content)
+				if !strings.HasSuffix(content, "\n") {
+					fmt.Println("")
+				}
+			}
+			return nil
+		}
+
+		owner := ""
+		if gist.Owner != nil {
+			owner = " · by @" + gist.Owner.Login
+		}
+		updated := ""
+		if gist.UpdatedAt != nil {
+			updated = " · updated on " + gist.UpdatedAt.Format("2006-01-02")
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(gistTitle(gist))
+		fmt.Printf("%s · %s%s%s · %d revision(s)\n", gist.ID, gistVisibility(gist), owner, updated, len(gist.History))
+		fmt.Printf("URL: %s\n", gist.HtmlURL)
+		for _, name := range names {
+			file := gist.Files[name]
+			content, err := utils.GistFileContent(file)
+			if err != nil {
+				return err
+			}
+			fmt.Println("")
+			if file.Language != "" {
+				color.New(color.Bold).Printf("%s (%s)\n", name, file.Language)
+			} else {
+				color.New(color.Bold).Println(name)
+			}
+			fmt.Println(strings.TrimRight(content, "\n"))
+		}
+		return nil
+	},
+}
+
+var gistEdit = cli.Command{
+	Name:      "edit",
+	Usage:     "Edits a gist's description or files",
+	ArgsUsage: "(--desc TEXT) (--add FILE...) (--remove NAME...) (--file NAME) [id]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "desc",
+			Usage: "new description of the gist",
+		},
+		cli.StringSliceFlag{
+			Name:  "add",
+			Usage: "adds or replaces a file with a local one. May be repeated",
+		},
+		cli.StringSliceFlag{
+			Name:  "remove",
+			Usage: "removes a file from the gist. May be repeated",
+		},
+		cli.StringFlag{
+			Name:  "file",
+			Usage: "file to open on the editor, which is created if needed",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh gist edit (--desc TEXT) (--add FILE...) (--remove NAME...) (--file NAME) [id]")
+		}
+
+		gistLogger.Timing("One moment, please...")
+		gist, resp := utils.GetGist(utils.GistID(c.Args()[0]), "")
+		utils.HandleClientError(resp, gistLogger)
+		names := utils.GistFileNames(gist)
+
+		params := octokit.M{}
+		files := map[string]interface{}{}
+		if c.IsSet("desc") {
+			params["description"] = c.String("desc")
+		}
+		for _, path := range c.StringSlice("add") {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			files[filepath.Base(path)] = octokit.M{"content": string(data)}
+		}
+		for _, name := range c.StringSlice("remove") {
+			name, err := gistFileName(gist, name)
+			if err != nil {
+				return err
+			}
+			// Files are removed by setting them to null
+			files[name] = nil
+		}
+
+		// Without any other changes, a file is edited on the user's editor
+		if len(params) == 0 && len(files) == 0 {
+			name := c.String("file")
+			if name == "" {
+				if len(names) == 1 {
+					name = names[0]
+				} else if !utils.IsInteractive() {
+					return fmt.Errorf("gist %s has %d files. Please pick one using --file", gist.ID, len(names))
+				} else if i := utils.Choose("Which file should be edited?", names); i == -1 {
+					return fmt.Errorf("aborting")
+				} else {
+					name = names[i]
+				}
+			}
+			initial := ""
+			if file, ok := gist.Files[name]; ok {
+				content, err := utils.GistFileContent(file)
+				if err != nil {
+					return err
+				}
+				initial = content
+			}
+			content, err := utils.EditText(initial)
+			if err != nil {
+				return err
+			}
+			if content == "" {
+				return fmt.Errorf("aborting due to an empty file")
+			}
+			if content == strings.TrimSpace(initial) {
+				gistLogger.Info("No changes made to %s", name)
+				return nil
+			}
+			files[name] = octokit.M{"content": content + "\n"}
+		}
+		if len(files) > 0 {
+			params["files"] = files
+		}
+
+		gistLogger.Timing("Updating gist %s", gist.ID)
+		_, resp = utils.NewClient().Gists().Update(&octokit.GistsURL, octokit.M{"gist_id": gist.ID}, params)
+		utils.HandleClientError(resp, gistLogger)
+		gistLogger.Success("Updated %s", gist.HtmlURL)
+		return nil
+	},
+}
+
+var gistClone = cli.Command{
+	Name:      "clone",
+	Usage:     "Clones a gist into a local directory",
+	ArgsUsage: "[id] ([directory])",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 1 || len(c.Args()) > 2 {
+			return fmt.Errorf("usage: gh gist clone [id] ([directory])")
+		}
+
+		gistLogger.Timing("One moment, please...")
+		gist, resp := utils.GetGist(utils.GistID(c.Args()[0]), "")
+		utils.HandleClientError(resp, gistLogger)
+		dir := gist.ID
+		if len(c.Args()) == 2 {
+			dir = c.Args()[1]
+		}
+
+		gistLogger.Timing("Cloning %s into %s", gist.ID, dir)
+		if _, err := utils.Git("clone", string(gist.GitPullURL), dir); err != nil {
+			return err
+		}
+		gistLogger.Success("Cloned %s into %s", gistTitle(gist), dir)
+		return nil
+	},
+}
+
+// starGist returns an action that stars or unstars one or more gists
+func starGist(star bool, verb string) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if len(c.Args()) < 1 {
+			return fmt.Errorf("usage: gh gist %s [id...]", c.Command.Name)
+		}
+		client := utils.NewClient()
+		for _, id := range gistIDs(c.Args()) {
+			params := octokit.M{"gist_id": id}
+			var resp *octokit.Result
+			if star {
+				_, resp = client.Gists().Star(&octokit.GistsStarURL, params)
+			} else {
+				_, resp = client.Gists().Unstar(&octokit.GistsStarURL, params)
+			}
+			utils.HandleClientError(resp, gistLogger)
+			gistLogger.Success("%s %s", verb, id)
+		}
+		return nil
+	}
+}
+
+var gistStar = cli.Command{
+	Name:      "star",
+	Usage:     "Stars one or more gists",
+	ArgsUsage: "[id...]",
+	Action:    starGist(true, "Starred"),
+}
+
+var gistUnstar = cli.Command{
+	Name:      "unstar",
+	Usage:     "Unstars one or more gists",
+	ArgsUsage: "[id...]",
+	Action:    starGist(false, "Unstarred"),
+}
+
+var gistFork = cli.Command{
+	Name:      "fork",
+	Usage:     "Forks a gist into the authenticated user's account",
+	ArgsUsage: "[id]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh gist fork [id]")
+		}
+
+		gistLogger.Timing("One moment, please...")
+		fork, resp := utils.NewClient().Gists().Fork(&octokit.GistsForksURL, octokit.M{"gist_id": utils.GistID(c.Args()[0])})
+		utils.HandleClientError(resp, gistLogger)
+		gistLogger.Success("Forked into %s", fork.HtmlURL)
+		return nil
+	},
+}
+
+var gistRm = cli.Command{
+	Name:      "rm",
+	Aliases:   []string{"delete"},
+	Usage:     "Deletes one or more gists",
+	ArgsUsage: "[id...]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 1 {
+			return fmt.Errorf("usage: gh gist rm [id...]")
+		}
+
+		client := utils.NewClient()
+		for _, id := range gistIDs(c.Args()) {
+			gist, resp := utils.GetGist(id, "")
+			utils.HandleClientError(resp, gistLogger)
+			if !utils.Confirm(fmt.Sprintf("Delete %s gist %s (%s)? y/[n]", gistVisibility(gist), gist.ID, gistTitle(gist)), false) {
+				gistLogger.Info("Skipping %s", gist.ID)
+				continue
+			}
+			_, resp = client.Gists().Delete(&octokit.GistsURL, octokit.M{"gist_id": gist.ID})
+			utils.HandleClientError(resp, gistLogger)
+			gistLogger.Success("Deleted %s", gist.ID)
+		}
+		return nil
+	},
+}
+
+// Gist exposes gist-related commands
+var Gist = cli.Command{
+	Name:    "gist",
+	Aliases: []string{"gists"},
+	Usage:   "Manages gists",
+	Subcommands: []cli.Command{
+		gistList,
+		gistView,
+		gistCreate,
+		gistEdit,
+		gistClone,
+		gistStar,
+		gistUnstar,
+		gistFork,
+		gistRm,
+	},
+}
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var invitesLogger = utils.Logger.WithExtra("invites")
+
+func invitesListAction(c *cli.Context) error {
+	invitesLogger.Timing("Fetching your invitations...")
+	invitations, err := utils.GetAllUserInvitations()
+	if err != nil {
+		if err, ok := err.(*octokit.ResponseError); ok {
+			invitesLogger.Error("%s", utils.FormatError(err))
+			os.Exit(1)
+		}
+		return err
+	}
+	if len(invitations) == 0 {
+		fmt.Println("No pending invitations")
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Repository", "Inviter", "Permission", "Created", "Expired?"})
+	table.SetAutoFormatHeaders(true)
+	for _, inv := range invitations {
+		row := invitationRow(inv)
+		repo := ""
+		if inv.Repository != nil {
+			repo = inv.Repository.FullName
+		}
+		table.Append([]string{row[0], repo, row[2], row[3], row[4], row[5]})
+	}
+	table.Render()
+	return nil
+}
+
+// invitationRepository names the repository an invitation is for, which is
+// absent when the repository was since deleted
+func invitationRepository(inv *utils.RepositoryInvitation) string {
+	if inv.Repository == nil {
+		return fmt.Sprintf("the repository of invitation #%d", inv.ID)
+	}
+	return inv.Repository.FullName
+}
+
+// findUserInvitation looks for an invitation addressed to the authenticated
+// user by its ID or by the repository name
+func findUserInvitation(needle string) (*utils.RepositoryInvitation, error) {
+	invitations, err := utils.GetAllUserInvitations()
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invitations {
+		if strconv.Itoa(inv.ID) == needle || (inv.Repository != nil && strings.EqualFold(inv.Repository.FullName, needle)) {
+			inv := inv
+			return &inv, nil
+		}
+	}
+	return nil, fmt.Errorf("no pending invitation matching '%s' was found", needle)
+}
+
+var invitesList = cli.Command{
+	Name:   "list",
+	Usage:  "Lists repository invitations addressed to you",
+	Action: invitesListAction,
+}
+
+var invitesAccept = cli.Command{
+	Name:      "accept",
+	Usage:     "Accepts a repository invitation",
+	ArgsUsage: "[invitation-id|owner/repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh invites accept [invitation-id|owner/repository]")
+		}
+		invitesLogger.Timing("One moment, please...")
+		inv, err := findUserInvitation(c.Args()[0])
+		if err != nil {
+			return err
+		}
+		resp := utils.APIRequest("PATCH", &utils.UserInvitationsURL, octokit.M{"id": inv.ID}, nil, nil)
+		utils.HandleClientError(resp, invitesLogger)
+		invitesLogger.Success("You're now a collaborator on %s", invitationRepository(inv))
+		return nil
+	},
+}
+
+var invitesDecline = cli.Command{
+	Name:      "decline",
+	Usage:     "Declines a repository invitation",
+	ArgsUsage: "[invitation-id|owner/repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh invites decline [invitation-id|owner/repository]")
+		}
+		invitesLogger.Timing("One moment, please...")
+		inv, err := findUserInvitation(c.Args()[0])
+		if err != nil {
+			return err
+		}
+		if !utils.Confirm(fmt.Sprintf("Decline invitation to %s? y/[n]", invitationRepository(inv)), false) {
+			return fmt.Errorf("aborting")
+		}
+		resp := utils.APIRequest("DELETE", &utils.UserInvitationsURL, octokit.M{"id": inv.ID}, nil, nil)
+		utils.HandleClientError(resp, invitesLogger)
+		invitesLogger.Success("Declined invitation to %s", invitationRepository(inv))
+		return nil
+	},
+}
+
+// Invites exposes commands handling repository invitations addressed to the
+// authenticated user
+var Invites = cli.Command{
+	Name:   "invites",
+	Usage:  "Manages repository invitations addressed to you",
+	Action: invitesListAction,
+	Subcommands: []cli.Command{
+		invitesList,
+		invitesAccept,
+		invitesDecline,
+	},
+}
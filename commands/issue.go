@@ -0,0 +1,448 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var issueLogger = utils.Logger.WithExtra("issue")
+
+var bodyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "body",
+		Usage: "text of the body",
+	},
+	cli.StringFlag{
+		Name:  "body-file",
+		Usage: "reads the body from a file. Use '-' to read from the standard input",
+	},
+}
+
+// issueNumber parses an issue or pull request number, optionally prefixed
+// by #
+func issueNumber(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(s, "#"))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("'%s' is not a valid issue number", s)
+	}
+	return n, nil
+}
+
+// readFlagText obtains a text from a flag, or from a file given by the same
+// flag suffixed with -file. A file named '-' refers to the standard input.
+// Returns false in case neither flag was provided.
+func readFlagText(c *cli.Context, name string) (string, bool, error) {
+	if c.IsSet(name) {
+		return c.String(name), true, nil
+	}
+	path := c.String(name + "-file")
+	if path == "" {
+		return "", false, nil
+	}
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+// readBody obtains a body from the --body or --body-file flags. When neither
+// is provided, the body is read from the standard input if it is not a
+// terminal, or from the user's editor otherwise.
+func readBody(c *cli.Context, required bool) (string, error) {
+	body, ok, err := readFlagText(c, "body")
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		if !utils.IsInteractive() {
+			data, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				return "", err
+			}
+			body = string(data)
+		} else if body, err = utils.EditText(""); err != nil {
+			return "", err
+		}
+	}
+	body = strings.TrimSpace(body)
+	if required && body == "" {
+		return "", fmt.Errorf("aborting due to an empty body")
+	}
+	return body, nil
+}
+
+func issueLabels(i *utils.Issue) string {
+	labels := []string{}
+	for _, l := range i.Labels {
+		labels = append(labels, l.Name)
+	}
+	return strings.Join(labels, ", ")
+}
+
+func issueAssignees(i *utils.Issue) string {
+	assignees := []string{}
+	for _, u := range i.Assignees {
+		assignees = append(assignees, "@"+u.Login)
+	}
+	if len(assignees) == 0 && i.Assignee.Login != "" {
+		assignees = append(assignees, "@"+i.Assignee.Login)
+	}
+	return strings.Join(assignees, ", ")
+}
+
+// printComments prints comments of an issue or pull request in the order
+// they were made
+func printComments(comments []octokit.IssueComment) {
+	for _, comment := range comments {
+		created := ""
+		if comment.CreatedAt != nil {
+			created = comment.CreatedAt.Format("2006-01-02 15:04")
+		}
+		fmt.Println("")
+		color.New(color.Bold).Printf("@%s commented on %s\n", comment.User.Login, created)
+		fmt.Println(strings.TrimSpace(comment.Body))
+	}
+}
+
+// repoAndNumbers parses a repository followed by one or more issue numbers
+func repoAndNumbers(args []string) (utils.RepoURL, []int, error) {
+	repoURL := utils.RepoURLFromString(args[0])
+	repoURL.AutoComplete()
+	numbers := []int{}
+	for _, arg := range args[1:] {
+		n, err := issueNumber(arg)
+		if err != nil {
+			return repoURL, nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	return repoURL, numbers, nil
+}
+
+var issueList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists issues of a repository",
+	ArgsUsage: "(--state open|closed|all) (--label LABEL...) (--assignee USERNAME) (--milestone MILESTONE) (--author USERNAME) (--limit N) [repository]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "state",
+			Usage: "either 'open', 'closed' or 'all'",
+			Value: "open",
+		},
+		cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "only lists issues with a given label. May be repeated",
+		},
+		cli.StringFlag{
+			Name:  "assignee",
+			Usage: "only lists issues assigned to a given user. Use 'none' for unassigned issues",
+		},
+		cli.StringFlag{
+			Name:  "milestone",
+			Usage: "only lists issues under a milestone, given by its title or number. Use 'none' for issues without milestones",
+		},
+		cli.StringFlag{
+			Name:  "author",
+			Usage: "only lists issues opened by a given user",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "maximum number of issues to list. Use 0 to list all of them",
+			Value: 30,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh issue list (--state open|closed|all) (--label LABEL...) (--assignee USERNAME) (--milestone MILESTONE) (--author USERNAME) (--limit N) [repository]")
+		}
+		state := strings.ToLower(c.String("state"))
+		if state != "open" && state != "closed" && state != "all" {
+			return fmt.Errorf("when filtering by state, please specify either 'open', 'closed' or 'all'")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		issueLogger.Timing("One moment, please...")
+		filters := octokit.M{"state": state}
+		if labels := c.StringSlice("label"); len(labels) > 0 {
+			filters["labels"] = strings.Join(labels, ",")
+		}
+		if assignee := c.String("assignee"); assignee != "" {
+			filters["assignee"] = strings.TrimPrefix(assignee, "@")
+		}
+		if author := c.String("author"); author != "" {
+			filters["creator"] = strings.TrimPrefix(author, "@")
+		}
+		if milestone := c.String("milestone"); milestone != "" {
+			if milestone == "none" || milestone == "*" {
+				filters["milestone"] = milestone
+			} else {
+				m, err := utils.FindMilestone(&repoURL, milestone)
+				if err != nil {
+					if err, ok := err.(*octokit.ResponseError); ok {
+						issueLogger.Error("%s", utils.FormatError(err))
+						os.Exit(1)
+					}
+					return err
+				}
+				filters["milestone"] = strconv.Itoa(m.Number)
+			}
+		}
+
+		issues, err := utils.GetIssues(&repoURL, filters, c.Int("limit"))
+		if err != nil {
+			if err, ok := err.(*octokit.ResponseError); ok {
+				issueLogger.Error("%s", utils.FormatError(err))
+				os.Exit(1)
+			}
+			return err
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		if len(issues) == 0 {
+			fmt.Println("No issues")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"#", "State", "Title", "Author", "Labels", "Assignees", "💬", "Updated"})
+		table.SetAutoFormatHeaders(true)
+		for _, i := range issues {
+			table.Append([]string{
+				strconv.Itoa(i.Number),
+				i.State,
+				i.Title,
+				"@" + i.User.Login,
+				issueLabels(&i),
+				issueAssignees(&i),
+				strconv.Itoa(i.Comments),
+				i.UpdatedAt.Format("2006-01-02"),
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var issueView = cli.Command{
+	Name:      "view",
+	Usage:     "Shows an issue along with its comments",
+	ArgsUsage: "[repository] [number]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh issue view [repository] [number]")
+		}
+		repoURL, numbers, err := repoAndNumbers(c.Args())
+		if err != nil {
+			return err
+		}
+
+		issueLogger.Timing("One moment, please...")
+		issue, resp := utils.GetIssue(&repoURL, numbers[0])
+		utils.HandleClientError(resp, issueLogger)
+		comments, err := utils.GetIssueComments(&repoURL, issue.Number)
+		if err != nil {
+			if err, ok := err.(*octokit.ResponseError); ok {
+				issueLogger.Error("%s", utils.FormatError(err))
+				os.Exit(1)
+			}
+			return err
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Printf("%s #%d\n", issue.Title, issue.Number)
+		fmt.Printf("%s · opened by @%s on %s · %d comment(s)\n", issue.State, issue.User.Login, issue.CreatedAt.Format("2006-01-02"), issue.Comments)
+		if labels := issueLabels(issue); labels != "" {
+			fmt.Printf("   Labels: %s\n", labels)
+		}
+		if assignees := issueAssignees(issue); assignees != "" {
+			fmt.Printf("Assignees: %s\n", assignees)
+		}
+		if issue.Milestone.Title != "" {
+			fmt.Printf("Milestone: %s\n", issue.Milestone.Title)
+		}
+		fmt.Printf("      URL: %s\n", issue.HTMLURL)
+		fmt.Println("")
+		if body := strings.TrimSpace(issue.Body); body != "" {
+			fmt.Println(body)
+		} else {
+			fmt.Println("No description provided.")
+		}
+		printComments(comments)
+		return nil
+	},
+}
+
+var issueCreate = cli.Command{
+	Name:      "create",
+	Usage:     "Opens a new issue",
+	ArgsUsage: "(--body TEXT|--body-file FILE) (--label LABEL...) (--assignee USERNAME...) (--milestone MILESTONE) [repository] [title]",
+	Flags: append([]cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "a label to apply to the issue. May be repeated",
+		},
+		cli.StringSliceFlag{
+			Name:  "assignee",
+			Usage: "a user to assign the issue to. May be repeated",
+		},
+		cli.StringFlag{
+			Name:  "milestone",
+			Usage: "title or number of a milestone to add the issue to",
+		},
+	}, bodyFlags...),
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh issue create (--body TEXT|--body-file FILE) (--label LABEL...) (--assignee USERNAME...) (--milestone MILESTONE) [repository] [title]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+		title := strings.TrimSpace(c.Args()[1])
+		if title == "" {
+			return fmt.Errorf("a title is required")
+		}
+
+		body, err := readBody(c, false)
+		if err != nil {
+			return err
+		}
+		params := octokit.M{"title": title, "body": body}
+		if labels := c.StringSlice("label"); len(labels) > 0 {
+			params["labels"] = labels
+		}
+		if assignees := c.StringSlice("assignee"); len(assignees) > 0 {
+			logins := []string{}
+			for _, a := range assignees {
+				logins = append(logins, strings.TrimPrefix(a, "@"))
+			}
+			params["assignees"] = logins
+		}
+
+		issueLogger.Timing("One moment, please...")
+		if milestone := c.String("milestone"); milestone != "" {
+			m, err := utils.FindMilestone(&repoURL, milestone)
+			if err != nil {
+				return err
+			}
+			params["milestone"] = m.Number
+		}
+
+		var issue utils.Issue
+		resp := utils.APIRequest("POST", &octokit.RepoIssuesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}, params, &issue)
+		utils.HandleClientError(resp, issueLogger)
+		issueLogger.Success("Opened #%d: %s", issue.Number, issue.HTMLURL)
+		return nil
+	},
+}
+
+// setIssueState returns an action that changes the state of one or more
+// issues, optionally leaving a comment on each of them
+func setIssueState(state, verb string) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if len(c.Args()) < 2 {
+			return fmt.Errorf("usage: gh issue %s (--comment TEXT) [repository] [number...]", c.Command.Name)
+		}
+		repoURL, numbers, err := repoAndNumbers(c.Args())
+		if err != nil {
+			return err
+		}
+
+		client := utils.NewClient()
+		for _, n := range numbers {
+			params := octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "number": n}
+			if comment := c.String("comment"); comment != "" {
+				issueLogger.Timing("Commenting on #%d", n)
+				_, resp := client.IssueComments().Create(&octokit.IssueCommentsURL, params, octokit.M{"body": comment})
+				utils.HandleClientError(resp, issueLogger)
+			}
+			issueLogger.Timing("Updating #%d", n)
+			_, resp := client.Issues().Update(&octokit.RepoIssuesURL, params, octokit.IssueParams{State: state})
+			utils.HandleClientError(resp, issueLogger)
+			issueLogger.Success("%s #%d", verb, n)
+		}
+		return nil
+	}
+}
+
+var issueClose = cli.Command{
+	Name:      "close",
+	Usage:     "Closes one or more issues",
+	ArgsUsage: "(--comment TEXT) [repository] [number...]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "comment",
+			Usage: "a comment to leave before closing",
+		},
+	},
+	Action: setIssueState("closed", "Closed"),
+}
+
+var issueReopen = cli.Command{
+	Name:      "reopen",
+	Usage:     "Reopens one or more issues",
+	ArgsUsage: "(--comment TEXT) [repository] [number...]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "comment",
+			Usage: "a comment to leave before reopening",
+		},
+	},
+	Action: setIssueState("open", "Reopened"),
+}
+
+var issueComment = cli.Command{
+	Name:      "comment",
+	Usage:     "Comments on an issue",
+	ArgsUsage: "(--body TEXT|--body-file FILE) [repository] [number]",
+	Flags:     bodyFlags,
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh issue comment (--body TEXT|--body-file FILE) [repository] [number]")
+		}
+		repoURL, numbers, err := repoAndNumbers(c.Args())
+		if err != nil {
+			return err
+		}
+		body, err := readBody(c, true)
+		if err != nil {
+			return err
+		}
+
+		issueLogger.Timing("One moment, please...")
+		comment, resp := utils.NewClient().IssueComments().Create(&octokit.IssueCommentsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "number": numbers[0]}, octokit.M{"body": body})
+		utils.HandleClientError(resp, issueLogger)
+		issueLogger.Success("Commented on #%d: %s", numbers[0], comment.HTMLURL)
+		return nil
+	},
+}
+
+// Issue exposes issue-related commands
+var Issue = cli.Command{
+	Name:    "issue",
+	Aliases: []string{"issues", "i"},
+	Usage:   "Manages repository issues",
+	Subcommands: []cli.Command{
+		issueList,
+		issueView,
+		issueCreate,
+		issueClose,
+		issueReopen,
+		issueComment,
+	},
+}
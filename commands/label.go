@@ -0,0 +1,346 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var labelLogger = utils.Logger.WithExtra("label")
+
+func handleLabelError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		labelLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// findLabel looks up a label by name, ignoring case like GitHub does
+func findLabel(labels []utils.Label, name string) (*utils.Label, bool) {
+	for i, l := range labels {
+		if strings.EqualFold(l.Name, name) {
+			return &labels[i], true
+		}
+	}
+	return nil, false
+}
+
+var labelList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists labels of a repository",
+	ArgsUsage: "[repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh label list [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		labelLogger.Timing("One moment, please...")
+		labels, err := utils.GetAllLabels(&repoURL)
+		if err != nil {
+			return handleLabelError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		if len(labels) == 0 {
+			fmt.Println("No labels")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Color", "Description"})
+		table.SetAutoFormatHeaders(true)
+		for _, l := range labels {
+			table.Append([]string{l.Name, "#" + l.Color, l.Description})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var labelCreate = cli.Command{
+	Name:      "create",
+	Usage:     "Creates a label",
+	ArgsUsage: "(--color HEX) (--description TEXT) [repository] [name]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "color",
+			Usage: "hexadecimal color of the label, such as d73a4a",
+			Value: "ededed",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "a short description of the label",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh label create (--color HEX) (--description TEXT) [repository] [name]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+		labelColor, err := utils.LabelColor(c.String("color"))
+		if err != nil {
+			return err
+		}
+
+		labelLogger.Timing("One moment, please...")
+		label := utils.Label{Name: c.Args()[1], Color: labelColor, Description: c.String("description")}
+		resp := utils.APIRequest("POST", &octokit.RepoLabelsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}, label, &label)
+		utils.HandleClientError(resp, labelLogger)
+		labelLogger.Success("Created label %s on %s", label.Name, repoURL.ToURL())
+		return nil
+	},
+}
+
+var labelEdit = cli.Command{
+	Name:      "edit",
+	Usage:     "Updates a label",
+	ArgsUsage: "(--name NAME) (--color HEX) (--description TEXT) [repository] [name]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "new name of the label",
+		},
+		cli.StringFlag{
+			Name:  "color",
+			Usage: "new hexadecimal color of the label",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "new description of the label",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh label edit (--name NAME) (--color HEX) (--description TEXT) [repository] [name]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		params := octokit.M{}
+		if c.IsSet("name") {
+			params["new_name"] = c.String("name")
+		}
+		if c.IsSet("color") {
+			labelColor, err := utils.LabelColor(c.String("color"))
+			if err != nil {
+				return err
+			}
+			params["color"] = labelColor
+		}
+		if c.IsSet("description") {
+			params["description"] = c.String("description")
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("nothing to update. Please provide at least one option")
+		}
+
+		labelLogger.Timing("One moment, please...")
+		var label utils.Label
+		resp := utils.APIRequest("PATCH", &octokit.RepoLabelsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "name": c.Args()[1]}, params, &label)
+		utils.HandleClientError(resp, labelLogger)
+		labelLogger.Success("Updated label %s on %s", label.Name, repoURL.ToURL())
+		return nil
+	},
+}
+
+var labelDelete = cli.Command{
+	Name:      "delete",
+	Aliases:   []string{"rm"},
+	Usage:     "Deletes one or more labels",
+	ArgsUsage: "[repository] [name...]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 2 {
+			return fmt.Errorf("usage: gh label delete [repository] [name...]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+		names := c.Args()[1:]
+
+		if !utils.Confirm(fmt.Sprintf("Delete %s from %s? Labels will be removed from all issues and pull requests. y/[n]", strings.Join(names, ", "), repoURL.ToURL()), false) {
+			return fmt.Errorf("aborting")
+		}
+		for _, name := range names {
+			labelLogger.Timing("Deleting %s", name)
+			resp := utils.APIRequest("DELETE", &octokit.RepoLabelsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "name": name}, nil, nil)
+			utils.HandleClientError(resp, labelLogger)
+		}
+		labelLogger.Success("Deleted %d label(s) from %s", len(names), repoURL.ToURL())
+		return nil
+	},
+}
+
+// labelChange represents a change to be applied to a label by sync
+type labelChange struct {
+	repo   utils.RepoURL
+	kind   string
+	name   string
+	label  utils.Label
+	detail string
+}
+
+// labelChanges compares labels of a repository against the wanted ones
+func labelChanges(repo utils.RepoURL, current, wanted []utils.Label, deleteExtra bool) []labelChange {
+	changes := []labelChange{}
+	for _, w := range wanted {
+		l, ok := findLabel(current, w.Name)
+		if !ok {
+			changes = append(changes, labelChange{repo: repo, kind: "+", name: w.Name, label: w, detail: "#" + w.Color})
+			continue
+		}
+		details := []string{}
+		if l.Name != w.Name {
+			details = append(details, fmt.Sprintf("renamed from %s", l.Name))
+		}
+		if !strings.EqualFold(l.Color, w.Color) {
+			details = append(details, fmt.Sprintf("color #%s → #%s", l.Color, w.Color))
+		}
+		if l.Description != w.Description {
+			details = append(details, "description")
+		}
+		if len(details) > 0 {
+			changes = append(changes, labelChange{repo: repo, kind: "~", name: l.Name, label: w, detail: strings.Join(details, ", ")})
+		}
+	}
+	if deleteExtra {
+		for _, l := range current {
+			if _, ok := findLabel(wanted, l.Name); !ok {
+				changes = append(changes, labelChange{repo: repo, kind: "-", name: l.Name})
+			}
+		}
+	}
+	return changes
+}
+
+var labelSync = cli.Command{
+	Name:      "sync",
+	Usage:     "Synchronizes labels of repositories with a template repository or file",
+	ArgsUsage: "(--from REPOSITORY|--from-file FILE) (--to REPOSITORY...) (--delete-extra) (--dry-run)",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "a repository to copy labels from",
+		},
+		cli.StringFlag{
+			Name:  "from-file",
+			Usage: "a YAML or JSON file listing labels",
+		},
+		cli.StringSliceFlag{
+			Name:  "to",
+			Usage: "a repository to synchronize. May be repeated",
+		},
+		cli.BoolFlag{
+			Name:  "delete-extra",
+			Usage: "deletes labels absent from the source",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only shows changes, without applying them",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 0 || len(c.StringSlice("to")) == 0 {
+			return fmt.Errorf("usage: gh label sync (--from REPOSITORY|--from-file FILE) (--to REPOSITORY...) (--delete-extra) (--dry-run)")
+		}
+		if (c.String("from") == "") == (c.String("from-file") == "") {
+			return fmt.Errorf("please provide either --from or --from-file")
+		}
+
+		var wanted []utils.Label
+		var err error
+		if path := c.String("from-file"); path != "" {
+			if wanted, err = utils.ReadLabelsFile(path); err != nil {
+				return err
+			}
+		}
+
+		labelLogger.Timing("One moment, please...")
+		if from := c.String("from"); from != "" {
+			fromURL := utils.RepoURLFromString(from)
+			fromURL.AutoComplete()
+			if wanted, err = utils.GetAllLabels(&fromURL); err != nil {
+				return handleLabelError(err)
+			}
+		}
+		if len(wanted) == 0 && c.Bool("delete-extra") {
+			return fmt.Errorf("the source has no labels. Refusing to delete all labels")
+		}
+
+		changes := []labelChange{}
+		for _, to := range c.StringSlice("to") {
+			repoURL := utils.RepoURLFromString(to)
+			repoURL.AutoComplete()
+			current, err := utils.GetAllLabels(&repoURL)
+			if err != nil {
+				return handleLabelError(err)
+			}
+			changes = append(changes, labelChanges(repoURL, current, wanted, c.Bool("delete-extra"))...)
+		}
+
+		if len(changes) == 0 {
+			labelLogger.Success("All repositories are already in sync")
+			return nil
+		}
+
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Repository", "", "Label", "Notes"})
+		table.SetAutoFormatHeaders(true)
+		for _, ch := range changes {
+			table.Append([]string{ch.repo.ToURL(), ch.kind, ch.name, ch.detail})
+		}
+		table.Render()
+		fmt.Println("")
+
+		if c.Bool("dry-run") {
+			labelLogger.Info("Dry run: %d change(s) were not applied", len(changes))
+			return nil
+		}
+		if !utils.Confirm(fmt.Sprintf("Apply %d change(s) to %d repositories? y/[n]", len(changes), len(c.StringSlice("to"))), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		for _, ch := range changes {
+			params := octokit.M{"owner": ch.repo.Username, "repo": ch.repo.RepoName, "name": ch.name}
+			var resp *octokit.Result
+			switch ch.kind {
+			case "+":
+				labelLogger.Timing("Creating %s on %s", ch.label.Name, ch.repo.ToURL())
+				resp = utils.APIRequest("POST", &octokit.RepoLabelsURL, octokit.M{"owner": ch.repo.Username, "repo": ch.repo.RepoName}, ch.label, nil)
+			case "~":
+				labelLogger.Timing("Updating %s on %s", ch.name, ch.repo.ToURL())
+				update := octokit.M{"new_name": ch.label.Name, "color": ch.label.Color, "description": ch.label.Description}
+				resp = utils.APIRequest("PATCH", &octokit.RepoLabelsURL, params, update, nil)
+			case "-":
+				labelLogger.Timing("Deleting %s from %s", ch.name, ch.repo.ToURL())
+				resp = utils.APIRequest("DELETE", &octokit.RepoLabelsURL, params, nil, nil)
+			}
+			utils.HandleClientError(resp, labelLogger)
+		}
+		labelLogger.Success("Synchronized labels of %d repositories", len(c.StringSlice("to")))
+		return nil
+	},
+}
+
+// Label exposes label-related commands
+var Label = cli.Command{
+	Name:    "label",
+	Aliases: []string{"labels"},
+	Usage:   "Manages repository labels",
+	Subcommands: []cli.Command{
+		labelList,
+		labelCreate,
+		labelEdit,
+		labelDelete,
+		labelSync,
+	},
+}
@@ -0,0 +1,390 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var milestoneLogger = utils.Logger.WithExtra("milestone")
+
+func handleMilestoneError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		milestoneLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// progressBar draws a bar of a given width representing how many of a total
+// of items are done, followed by a percentage
+func progressBar(done, total, width int) string {
+	if total == 0 {
+		return strings.Repeat("░", width) + "   -"
+	}
+	filled := done * width / total
+	return fmt.Sprintf("%s%s %3d%%", strings.Repeat("█", filled), strings.Repeat("░", width-filled), done*100/total)
+}
+
+// milestoneDue parses a due date given as YYYY-MM-DD. An empty date returns
+// nil.
+func milestoneDue(date string) (*string, error) {
+	if date == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid date. Please use YYYY-MM-DD", date)
+	}
+	due := t.Format(time.RFC3339)
+	return &due, nil
+}
+
+func dueDate(m *octokit.Milestone) string {
+	if m.DueOn == nil {
+		return ""
+	}
+	return m.DueOn.Format("2006-01-02")
+}
+
+func formatDue(m *octokit.Milestone) string {
+	due := dueDate(m)
+	if due != "" && m.State == "open" && m.DueOn.Before(time.Now()) {
+		due += " (overdue)"
+	}
+	return due
+}
+
+// findMilestones looks up one or more milestones of a repository by their
+// titles or numbers
+func findMilestones(repoURL *utils.RepoURL, names []string) ([]octokit.Milestone, error) {
+	result := []octokit.Milestone{}
+	for _, name := range names {
+		m, err := utils.FindMilestone(repoURL, name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *m)
+	}
+	return result, nil
+}
+
+var milestoneList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists milestones of a repository along with their progress",
+	ArgsUsage: "(--state open|closed|all) [repository]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "state",
+			Usage: "either 'open', 'closed' or 'all'",
+			Value: "open",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh milestone list (--state open|closed|all) [repository]")
+		}
+		state := strings.ToLower(c.String("state"))
+		if state != "open" && state != "closed" && state != "all" {
+			return fmt.Errorf("when filtering by state, please specify either 'open', 'closed' or 'all'")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		milestoneLogger.Timing("One moment, please...")
+		milestones, err := utils.GetAllMilestones(&repoURL, state)
+		if err != nil {
+			return handleMilestoneError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		if len(milestones) == 0 {
+			fmt.Println("No milestones")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"#", "Title", "State", "Due", "Open", "Closed", "Progress"})
+		table.SetAutoFormatHeaders(true)
+		for _, m := range milestones {
+			table.Append([]string{
+				strconv.Itoa(m.Number),
+				m.Title,
+				m.State,
+				formatDue(&m),
+				strconv.Itoa(m.OpenIssues),
+				strconv.Itoa(m.ClosedIssues),
+				progressBar(m.ClosedIssues, m.OpenIssues+m.ClosedIssues, 10),
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var milestoneCreate = cli.Command{
+	Name:      "create",
+	Usage:     "Creates a milestone",
+	ArgsUsage: "(--due YYYY-MM-DD) (--description TEXT) [repository] [title]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "due",
+			Usage: "due date of the milestone, as YYYY-MM-DD",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "description of the milestone",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh milestone create (--due YYYY-MM-DD) (--description TEXT) [repository] [title]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+		title := strings.TrimSpace(c.Args()[1])
+		if title == "" {
+			return fmt.Errorf("a title is required")
+		}
+		due, err := milestoneDue(c.String("due"))
+		if err != nil {
+			return err
+		}
+
+		milestoneLogger.Timing("One moment, please...")
+		params := octokit.M{"title": title, "description": c.String("description")}
+		if due != nil {
+			params["due_on"] = *due
+		}
+		milestone, resp := utils.NewClient().Milestones().Create(&octokit.MilestonesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}, params)
+		utils.HandleClientError(resp, milestoneLogger)
+		milestoneLogger.Success("Created milestone #%d: %s", milestone.Number, milestone.HTMLURL)
+		return nil
+	},
+}
+
+var milestoneClose = cli.Command{
+	Name:      "close",
+	Usage:     "Closes one or more milestones",
+	ArgsUsage: "[repository] [title|number...]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 2 {
+			return fmt.Errorf("usage: gh milestone close [repository] [title|number...]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		milestoneLogger.Timing("One moment, please...")
+		milestones, err := findMilestones(&repoURL, c.Args()[1:])
+		if err != nil {
+			return handleMilestoneError(err)
+		}
+		for _, m := range milestones {
+			if m.OpenIssues > 0 {
+				milestoneLogger.Warn("%s still has %d open issue(s)", m.Title, m.OpenIssues)
+			}
+			milestoneLogger.Timing("Closing %s", m.Title)
+			resp := utils.APIRequest("PATCH", &utils.RepoMilestonesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "number": m.Number}, octokit.M{"state": "closed"}, nil)
+			utils.HandleClientError(resp, milestoneLogger)
+		}
+		milestoneLogger.Success("Closed %d milestone(s)", len(milestones))
+		return nil
+	},
+}
+
+var milestoneDelete = cli.Command{
+	Name:      "delete",
+	Aliases:   []string{"rm"},
+	Usage:     "Deletes one or more milestones",
+	ArgsUsage: "[repository] [title|number...]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 2 {
+			return fmt.Errorf("usage: gh milestone delete [repository] [title|number...]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		milestoneLogger.Timing("One moment, please...")
+		milestones, err := findMilestones(&repoURL, c.Args()[1:])
+		if err != nil {
+			return handleMilestoneError(err)
+		}
+		titles := []string{}
+		for _, m := range milestones {
+			titles = append(titles, m.Title)
+		}
+		if !utils.Confirm(fmt.Sprintf("Delete %s from %s? Issues will be kept, but removed from them. y/[n]", strings.Join(titles, ", "), repoURL.ToURL()), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		for _, m := range milestones {
+			milestoneLogger.Timing("Deleting %s", m.Title)
+			resp := utils.APIRequest("DELETE", &utils.RepoMilestonesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "number": m.Number}, nil, nil)
+			utils.HandleClientError(resp, milestoneLogger)
+		}
+		milestoneLogger.Success("Deleted %d milestone(s)", len(milestones))
+		return nil
+	},
+}
+
+// milestoneChange represents a milestone to be created or updated on a
+// repository by copy
+type milestoneChange struct {
+	repo   utils.RepoURL
+	kind   string
+	number int
+	source octokit.Milestone
+	detail string
+}
+
+var milestoneCopy = cli.Command{
+	Name:      "copy",
+	Usage:     "Replicates milestones of a repository across other repositories",
+	ArgsUsage: "(--milestone TITLE...) (--to REPOSITORY...) (--dry-run) [repository]",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "milestone",
+			Usage: "title or number of a milestone to copy. May be repeated. Defaults to all open milestones",
+		},
+		cli.StringSliceFlag{
+			Name:  "to",
+			Usage: "a repository to copy milestones to. May be repeated",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only shows changes, without applying them",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 || len(c.StringSlice("to")) == 0 {
+			return fmt.Errorf("usage: gh milestone copy (--milestone TITLE...) (--to REPOSITORY...) (--dry-run) [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		milestoneLogger.Timing("One moment, please...")
+		var sources []octokit.Milestone
+		var err error
+		if names := c.StringSlice("milestone"); len(names) > 0 {
+			sources, err = findMilestones(&repoURL, names)
+		} else {
+			sources, err = utils.GetAllMilestones(&repoURL, "open")
+		}
+		if err != nil {
+			return handleMilestoneError(err)
+		}
+		if len(sources) == 0 {
+			return fmt.Errorf("%s has no open milestones to copy", repoURL.ToURL())
+		}
+
+		changes := []milestoneChange{}
+		for _, to := range c.StringSlice("to") {
+			target := utils.RepoURLFromString(to)
+			target.AutoComplete()
+			existing, err := utils.GetAllMilestones(&target, "all")
+			if err != nil {
+				return handleMilestoneError(err)
+			}
+			for _, src := range sources {
+				var match *octokit.Milestone
+				for i, m := range existing {
+					if strings.EqualFold(m.Title, src.Title) {
+						match = &existing[i]
+						break
+					}
+				}
+				if match == nil {
+					changes = append(changes, milestoneChange{repo: target, kind: "+", source: src, detail: dueDate(&src)})
+					continue
+				}
+				details := []string{}
+				if dueDate(match) != dueDate(&src) {
+					details = append(details, fmt.Sprintf("due %s → %s", orNone(dueDate(match)), orNone(dueDate(&src))))
+				}
+				if match.Description != src.Description {
+					details = append(details, "description")
+				}
+				if match.State != src.State {
+					details = append(details, fmt.Sprintf("%s → %s", match.State, src.State))
+				}
+				if len(details) > 0 {
+					changes = append(changes, milestoneChange{repo: target, kind: "~", number: match.Number, source: src, detail: strings.Join(details, ", ")})
+				}
+			}
+		}
+
+		if len(changes) == 0 {
+			milestoneLogger.Success("All repositories are already in sync")
+			return nil
+		}
+
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Repository", "", "Milestone", "Notes"})
+		table.SetAutoFormatHeaders(true)
+		for _, ch := range changes {
+			table.Append([]string{ch.repo.ToURL(), ch.kind, ch.source.Title, ch.detail})
+		}
+		table.Render()
+		fmt.Println("")
+
+		if c.Bool("dry-run") {
+			milestoneLogger.Info("Dry run: %d change(s) were not applied", len(changes))
+			return nil
+		}
+		if !utils.Confirm(fmt.Sprintf("Apply %d change(s) to %d repositories? y/[n]", len(changes), len(c.StringSlice("to"))), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		for _, ch := range changes {
+			params := octokit.M{"owner": ch.repo.Username, "repo": ch.repo.RepoName}
+			input := octokit.M{
+				"title":       ch.source.Title,
+				"state":       ch.source.State,
+				"description": ch.source.Description,
+				"due_on":      ch.source.DueOn,
+			}
+			var resp *octokit.Result
+			if ch.kind == "+" {
+				milestoneLogger.Timing("Creating %s on %s", ch.source.Title, ch.repo.ToURL())
+				resp = utils.APIRequest("POST", &utils.RepoMilestonesURL, params, input, nil)
+			} else {
+				milestoneLogger.Timing("Updating %s on %s", ch.source.Title, ch.repo.ToURL())
+				params["number"] = ch.number
+				resp = utils.APIRequest("PATCH", &utils.RepoMilestonesURL, params, input, nil)
+			}
+			utils.HandleClientError(resp, milestoneLogger)
+		}
+		milestoneLogger.Success("Copied milestones to %d repositories", len(c.StringSlice("to")))
+		return nil
+	},
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// Milestone exposes milestone-related commands
+var Milestone = cli.Command{
+	Name:    "milestone",
+	Aliases: []string{"milestones"},
+	Usage:   "Manages repository milestones",
+	Subcommands: []cli.Command{
+		milestoneList,
+		milestoneCreate,
+		milestoneClose,
+		milestoneDelete,
+		milestoneCopy,
+	},
+}
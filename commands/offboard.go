@@ -0,0 +1,291 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var offboardLogger = utils.Logger.WithExtra("offboard")
+
+// offboardAction represents a single grant to be revoked while offboarding a
+// user
+type offboardAction struct {
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	uri    *octokit.Hyperlink
+	params octokit.M
+}
+
+type offboardReceipt struct {
+	User     string           `json:"user"`
+	Date     time.Time        `json:"date"`
+	Removed  []offboardAction `json:"removed"`
+	NotFound []offboardAction `json:"not_found,omitempty"`
+	Failed   []offboardAction `json:"failed,omitempty"`
+}
+
+// inheritedMembershipGone determines whether a team grant that could not be
+// found was a membership inherited from a child team, revoked along with it
+func inheritedMembershipGone(a offboardAction, username string) bool {
+	member, err := utils.IsTeamMember(a.params["id"].(int), username)
+	return err == nil && !member
+}
+
+// offboardRepoActions returns direct grants and pending invitations of a
+// given user on a list of repositories
+func offboardRepoActions(repos []octokit.Repository, username string) ([]offboardAction, error) {
+	actions := []offboardAction{}
+	for _, repo := range repos {
+		r := utils.RepoURL{Username: repo.Owner.Login, RepoName: repo.Name}
+		offboardLogger.Timing("Checking %s...", repo.FullName)
+		invitations, err := utils.GetAllInvitationsForRepo(&r)
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range invitations {
+			if inv.Invitee != nil && strings.EqualFold(inv.Invitee.Login, username) {
+				actions = append(actions, offboardAction{
+					Kind:   "repository-invitation",
+					Target: repo.FullName,
+					Detail: inv.Permissions,
+					uri:    &utils.RepositoryInvitationsURL,
+					params: octokit.M{"owner": r.Username, "repo": r.RepoName, "id": inv.ID},
+				})
+			}
+		}
+		collabs, err := utils.GetCollabsByAffiliation(&r, "direct")
+		if err != nil {
+			return nil, err
+		}
+		for _, collab := range collabs {
+			if strings.EqualFold(collab.Login, username) {
+				actions = append(actions, offboardAction{
+					Kind:   "collaborator",
+					Target: repo.FullName,
+					Detail: collab.Role(),
+					uri:    &octokit.CollaboratorsURL,
+					params: octokit.M{"owner": r.Username, "repo": r.RepoName, "username": collab.Login},
+				})
+			}
+		}
+	}
+	return actions, nil
+}
+
+// offboardOrgActions returns all grants of a given user on an organization:
+// direct repository grants, pending invitations, team memberships and the
+// organization membership itself
+func offboardOrgActions(org, username string) ([]offboardAction, error) {
+	repos, err := utils.GetAllReposForOrg(org)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := offboardRepoActions(repos, username)
+	if err != nil {
+		return nil, err
+	}
+
+	offboardLogger.Timing("Checking %s invitations...", org)
+	invitations, err := utils.GetAllInvitationsForOrg(org)
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invitations {
+		if strings.EqualFold(inv.Login, username) {
+			actions = append(actions, offboardAction{
+				Kind:   "organization-invitation",
+				Target: org,
+				Detail: inv.Role,
+				uri:    &utils.OrganizationInvitationsURL,
+				params: octokit.M{"org": org, "id": inv.ID},
+			})
+		}
+	}
+
+	offboardLogger.Timing("Checking %s teams...", org)
+	teams, err := utils.GetUserTeams(org, username)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range teams {
+		actions = append(actions, offboardAction{
+			Kind:   "team",
+			Target: fmt.Sprintf("%s/%s", org, t.Slug),
+			uri:    &octokit.TeamMembershipURL,
+			params: octokit.M{"id": t.ID, "username": username},
+		})
+	}
+
+	membership, err := utils.GetOrgMembership(org, username)
+	if err != nil {
+		return nil, err
+	}
+	if membership != nil {
+		actions = append(actions, offboardAction{
+			Kind:   "organization-membership",
+			Target: org,
+			Detail: membership.Role,
+			uri:    &utils.OrganizationMembershipURL,
+			params: octokit.M{"org": org, "username": username},
+		})
+	}
+	return actions, nil
+}
+
+// Offboard revokes every access a user has on the given organizations and on
+// repositories owned by the authenticated user
+var Offboard = cli.Command{
+	Name:      "offboard",
+	Usage:     "Removes a user from teams, repositories and organizations",
+	ArgsUsage: "(--org ORG...) (--receipt FILE) [username]",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "org",
+			Usage: "an organization to remove the user from. May be repeated",
+		},
+		cli.StringFlag{
+			Name:  "receipt",
+			Usage: "writes the JSON receipt to a file instead of the standard output",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh offboard (--org ORG...) (--receipt FILE) [username]")
+		}
+		username := strings.TrimPrefix(c.Args()[0], "@")
+
+		offboardLogger.Timing("One moment, please...")
+		url, err := octokit.CurrentUserURL.Expand(nil)
+		if err != nil {
+			return err
+		}
+		me, resp := utils.NewClient().Users(url).One()
+		utils.HandleClientError(resp, offboardLogger)
+		if strings.EqualFold(me.Login, username) {
+			return fmt.Errorf("you cannot offboard yourself")
+		}
+
+		userRepos, err := utils.GetAllUserRepositories()
+		if err != nil {
+			return handleOffboardError(err)
+		}
+		owned := []octokit.Repository{}
+		for _, repo := range userRepos {
+			if strings.EqualFold(repo.Owner.Login, me.Login) {
+				owned = append(owned, repo)
+			}
+		}
+		actions, err := offboardRepoActions(owned, username)
+		if err != nil {
+			return handleOffboardError(err)
+		}
+		for _, org := range c.StringSlice("org") {
+			orgActions, err := offboardOrgActions(org, username)
+			if err != nil {
+				return handleOffboardError(err)
+			}
+			actions = append(actions, orgActions...)
+		}
+
+		if len(actions) == 0 {
+			offboardLogger.Success("@%s has no access to revoke", username)
+			return nil
+		}
+
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"#", "Kind", "Target", "Role"})
+		table.SetAutoFormatHeaders(true)
+		for i, a := range actions {
+			table.Append([]string{strconv.Itoa(i + 1), a.Kind, a.Target, a.Detail})
+		}
+		table.Render()
+		fmt.Println("")
+		if !utils.Confirm(fmt.Sprintf("Revoke all %d grant(s) of @%s? y/[n]", len(actions), username), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		receipt := offboardReceipt{
+			User:    username,
+			Date:    time.Now().UTC(),
+			Removed: []offboardAction{},
+		}
+		missingTeams := []offboardAction{}
+		for _, a := range actions {
+			offboardLogger.Timing("Revoking %s on %s", a.Kind, a.Target)
+			resp := utils.APIRequest("DELETE", a.uri, a.params, nil, nil)
+			// Team memberships inherited from child teams vanish once the
+			// child membership is revoked, which may only happen later on.
+			// Other grants may also be reported as missing when access to
+			// them is lacking, so those are not considered removed.
+			if utils.IsNotFound(resp) && a.Kind == "team" {
+				missingTeams = append(missingTeams, a)
+				continue
+			}
+			if utils.IsNotFound(resp) {
+				offboardLogger.Warn("Could not find %s on %s", a.Kind, a.Target)
+				receipt.NotFound = append(receipt.NotFound, a)
+				continue
+			}
+			if resp.HasError() {
+				a.Error = resp.Err.Error()
+				if err, ok := resp.Err.(*octokit.ResponseError); ok {
+					a.Error = strings.TrimSpace(utils.FormatError(err))
+				}
+				offboardLogger.Warn("Could not revoke %s on %s: %s", a.Kind, a.Target, a.Error)
+				receipt.Failed = append(receipt.Failed, a)
+				continue
+			}
+			receipt.Removed = append(receipt.Removed, a)
+		}
+		for _, a := range missingTeams {
+			if !inheritedMembershipGone(a, username) {
+				offboardLogger.Warn("Could not find %s on %s", a.Kind, a.Target)
+				receipt.NotFound = append(receipt.NotFound, a)
+				continue
+			}
+			receipt.Removed = append(receipt.Removed, a)
+		}
+
+		data, err := json.MarshalIndent(receipt, "", "  ")
+		if err != nil {
+			return err
+		}
+		if path := c.String("receipt"); path != "" {
+			if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+				return err
+			}
+			offboardLogger.Info("Receipt written to %s", path)
+		} else {
+			fmt.Println(string(data))
+		}
+
+		if len(receipt.Failed) > 0 || len(receipt.NotFound) > 0 {
+			offboardLogger.Warn("Revoked %d grant(s) of @%s; %d failed and %d could not be found", len(receipt.Removed), username, len(receipt.Failed), len(receipt.NotFound))
+			os.Exit(1)
+		}
+		offboardLogger.Success("Revoked %d grant(s) of @%s", len(receipt.Removed), username)
+		return nil
+	},
+}
+
+func handleOffboardError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		offboardLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
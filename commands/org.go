@@ -0,0 +1,392 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var orgLogger = utils.Logger.WithExtra("org")
+
+var orgRoleAliases = map[string]string{
+	"member": "direct_member",
+}
+
+func handleOrgError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		orgLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+var orgMembers = cli.Command{
+	Name:      "members",
+	Usage:     "Lists members of an organization",
+	ArgsUsage: "(--role admin|member) (--2fa-disabled) [org]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "role",
+			Usage: "only lists members holding a given role, either 'admin' or 'member'",
+		},
+		cli.BoolFlag{
+			Name:  "2fa-disabled",
+			Usage: "only lists members without two-factor authentication. Requires organization ownership",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh org members (--role admin|member) (--2fa-disabled) [org]")
+		}
+		role := strings.ToLower(c.String("role"))
+		if role != "" && role != "admin" && role != "member" {
+			return fmt.Errorf("when filtering by role, please specify either 'admin' or 'member'")
+		}
+		filter := ""
+		if c.Bool("2fa-disabled") {
+			filter = "2fa_disabled"
+		}
+		orgName := c.Args()[0]
+
+		orgLogger.Timing("One moment, please...")
+		members, err := utils.GetFilteredUsersForOrg(orgName, role, filter)
+		if err != nil {
+			return handleOrgError(err)
+		}
+		admins := map[string]bool{}
+		if role == "" {
+			users, err := utils.GetFilteredUsersForOrg(orgName, "admin", "")
+			if err != nil {
+				return handleOrgError(err)
+			}
+			for _, u := range users {
+				admins[u.Login] = true
+			}
+		}
+		logins := []string{}
+		for _, m := range members {
+			logins = append(logins, m.Login)
+		}
+		sort.Sort(utils.Alphabetic(logins))
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(orgName)
+		if len(logins) == 0 {
+			fmt.Println("No members")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"User", "Role"})
+		table.SetAutoFormatHeaders(true)
+		for _, login := range logins {
+			r := role
+			if r == "" {
+				r = "member"
+				if admins[login] {
+					r = "admin"
+				}
+			}
+			table.Append([]string{"@" + login, r})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var orgInvite = cli.Command{
+	Name:      "invite",
+	Usage:     "Invites a user to an organization",
+	ArgsUsage: "(--role admin|member|billing_manager) (--team TEAM-SLUG...) [org] [username]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "role",
+			Usage: "role of the user on the organization: 'admin', 'member' or 'billing_manager'",
+			Value: "member",
+		},
+		cli.StringSliceFlag{
+			Name:  "team",
+			Usage: "slug of a team the user will join once the invitation is accepted. May be repeated",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh org invite (--role admin|member|billing_manager) (--team TEAM-SLUG...) [org] [username]")
+		}
+		role := strings.ToLower(c.String("role"))
+		if v, present := orgRoleAliases[role]; present {
+			role = v
+		}
+		if role != "admin" && role != "direct_member" && role != "billing_manager" {
+			return fmt.Errorf("when defining a role, please specify either 'admin', 'member' or 'billing_manager'")
+		}
+		orgName := c.Args()[0]
+		username := strings.TrimPrefix(c.Args()[1], "@")
+
+		orgLogger.Timing("One moment, please...")
+		user, resp := utils.GetUser(username)
+		utils.HandleClientError(resp, orgLogger)
+
+		teamIDs := []int{}
+		for _, slug := range c.StringSlice("team") {
+			t, err := utils.GetTeamByName(orgName, slug, orgLogger, true)
+			if err != nil {
+				return err
+			}
+			teamIDs = append(teamIDs, t.ID)
+		}
+
+		fmt.Println("")
+		fmt.Println("Hey there! You're about to invite a new user to an organization.")
+		fmt.Printf("Mind checking out whether this is the @%s you're looking for?\n", username)
+		fmt.Println("")
+		printUserProfile(user)
+		fmt.Println("")
+		if !utils.Confirm(fmt.Sprintf("Continue inviting @%s to %s? [y]/n", username, orgName), true) {
+			return fmt.Errorf("aborting")
+		}
+
+		params := octokit.M{"invitee_id": user.ID, "role": role}
+		if len(teamIDs) > 0 {
+			params["team_ids"] = teamIDs
+		}
+		resp = utils.APIRequest("POST", &utils.OrganizationInvitationsURL, octokit.M{"org": orgName}, params, nil)
+		utils.HandleClientError(resp, orgLogger)
+		orgLogger.Success("Invited @%s to %s", username, orgName)
+		return nil
+	},
+}
+
+var orgRemove = cli.Command{
+	Name:      "remove",
+	Usage:     "Removes a user from an organization",
+	ArgsUsage: "[org] [username]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh org remove [org] [username]")
+		}
+		orgName := c.Args()[0]
+		username := strings.TrimPrefix(c.Args()[1], "@")
+
+		orgLogger.Timing("One moment, please...")
+		membership, err := utils.GetOrgMembership(orgName, username)
+		if err != nil {
+			return handleOrgError(err)
+		}
+		if membership == nil {
+			orgLogger.Warn("@%s does not belong to %s", username, orgName)
+			members, err := utils.GetAllUsersForOrg(orgName)
+			if err != nil {
+				return handleOrgError(err)
+			}
+			if username = utils.ResolveLogin(username, members); username == "" {
+				os.Exit(1)
+			}
+			if membership, err = utils.GetOrgMembership(orgName, username); err != nil {
+				return handleOrgError(err)
+			}
+		}
+
+		orgLogger.Timing("Looking for teams and repositories @%s has access to...", username)
+		teams, err := utils.GetUserTeams(orgName, username)
+		if err != nil {
+			return handleOrgError(err)
+		}
+		repos := map[string]string{}
+		for _, t := range teams {
+			teamRepos, err := utils.GetAllReposForTeam(t.ID)
+			if err != nil {
+				return handleOrgError(err)
+			}
+			for _, r := range teamRepos {
+				if utils.PermissionRank(r.Role()) > utils.PermissionRank(repos[r.FullName]) {
+					repos[r.FullName] = r.Role()
+				}
+			}
+		}
+
+		fmt.Println("")
+		fmt.Println("Hey! You're about to remove a user from an organization.")
+		if membership.Role == "admin" {
+			fmt.Printf("⚠️  WARNING! @%s is an owner of %s!\n", username, orgName)
+		}
+		if len(teams) > 0 {
+			fmt.Printf("\n@%s will lose membership on the following teams:\n", username)
+			for _, t := range teams {
+				fmt.Printf("    - %s (@%s)\n", t.Name, t.Slug)
+			}
+		}
+		if len(repos) > 0 {
+			names := []string{}
+			for name := range repos {
+				names = append(names, name)
+			}
+			sort.Sort(utils.Alphabetic(names))
+			fmt.Printf("\n@%s will lose access granted through those teams to:\n", username)
+			for _, name := range names {
+				fmt.Printf("    - %s (%s)\n", name, repos[name])
+			}
+		}
+		fmt.Println("")
+		if !utils.Confirm(fmt.Sprintf("Continue removing @%s from %s? y/[n]", username, orgName), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		resp := utils.APIRequest("DELETE", &utils.OrganizationMembershipURL, octokit.M{"org": orgName, "username": username}, nil, nil)
+		utils.HandleClientError(resp, orgLogger)
+		orgLogger.Success("Removed @%s from %s", username, orgName)
+		return nil
+	},
+}
+
+func orgInvitationsListAction(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		return fmt.Errorf("usage: gh org invitations list [org]")
+	}
+	orgName := c.Args()[0]
+	orgLogger.Timing("One moment, please...")
+	invitations, err := utils.GetAllInvitationsForOrg(orgName)
+	if err != nil {
+		return handleOrgError(err)
+	}
+
+	fmt.Println("")
+	color.New(color.Bold, color.Underline).Println(orgName)
+	if len(invitations) == 0 {
+		fmt.Println("No pending invitations")
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Invitee", "Role", "Teams", "Inviter", "Created"})
+	table.SetAutoFormatHeaders(true)
+	for _, inv := range invitations {
+		table.Append(orgInvitationRow(inv))
+	}
+	table.Render()
+	return nil
+}
+
+func orgInvitationRow(inv utils.OrgInvitation) []string {
+	invitee := inv.Email
+	if inv.Login != "" {
+		invitee = "@" + inv.Login
+	}
+	inviter := ""
+	if inv.Inviter != nil {
+		inviter = "@" + inv.Inviter.Login
+	}
+	created := ""
+	if inv.CreatedAt != nil {
+		created = inv.CreatedAt.Format("2006-01-02")
+	}
+	return []string{strconv.Itoa(inv.ID), invitee, inv.Role, strconv.Itoa(inv.TeamCount), inviter, created}
+}
+
+var orgInvitationsList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists pending invitations to join an organization",
+	ArgsUsage: "[org]",
+	Action:    orgInvitationsListAction,
+}
+
+var orgInvitationsCancel = cli.Command{
+	Name:      "cancel",
+	Usage:     "Cancels a pending invitation to join an organization",
+	ArgsUsage: "[org] [invitation-id|invitee-username|invitee-email]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh org invitations cancel [org] [invitation-id|invitee-username|invitee-email]")
+		}
+		orgName := c.Args()[0]
+		needle := strings.TrimPrefix(c.Args()[1], "@")
+		orgLogger.Timing("One moment, please...")
+		invitations, err := utils.GetAllInvitationsForOrg(orgName)
+		if err != nil {
+			return handleOrgError(err)
+		}
+		var inv *utils.OrgInvitation
+		for _, i := range invitations {
+			if strconv.Itoa(i.ID) == needle || (i.Login != "" && strings.EqualFold(i.Login, needle)) || (i.Email != "" && strings.EqualFold(i.Email, needle)) {
+				i := i
+				inv = &i
+				break
+			}
+		}
+		if inv == nil {
+			return fmt.Errorf("no pending invitation matching '%s' was found for %s", needle, orgName)
+		}
+		if !utils.Confirm(fmt.Sprintf("Cancel invitation #%d to %s? y/[n]", inv.ID, orgName), false) {
+			return fmt.Errorf("aborting")
+		}
+		resp := utils.APIRequest("DELETE", &utils.OrganizationInvitationsURL, octokit.M{"org": orgName, "id": inv.ID}, nil, nil)
+		utils.HandleClientError(resp, orgLogger)
+		orgLogger.Success("Cancelled invitation #%d to %s", inv.ID, orgName)
+		return nil
+	},
+}
+
+var orgInvitations = cli.Command{
+	Name:      "invitations",
+	Usage:     "Manages pending invitations to join an organization",
+	ArgsUsage: "[org]",
+	Action:    orgInvitationsListAction,
+	Subcommands: []cli.Command{
+		orgInvitationsList,
+		orgInvitationsCancel,
+	},
+}
+
+var orgOutsideCollaborators = cli.Command{
+	Name:      "outside-collaborators",
+	Usage:     "Lists users with access to organization repositories who are not members of it",
+	ArgsUsage: "[org]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh org outside-collaborators [org]")
+		}
+		orgName := c.Args()[0]
+		orgLogger.Timing("One moment, please...")
+		users, err := utils.GetOutsideCollaborators(orgName)
+		if err != nil {
+			return handleOrgError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(orgName)
+		if len(users) == 0 {
+			fmt.Println("No outside collaborators")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Users"})
+		table.SetAutoFormatHeaders(true)
+		for _, u := range users {
+			table.Append([]string{fmt.Sprintf("@%s", u.Login)})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+// Org exposes organization-related commands
+var Org = cli.Command{
+	Name:    "org",
+	Aliases: []string{"orgs"},
+	Usage:   "Manages organizations and their members",
+	Subcommands: []cli.Command{
+		orgView,
+		orgEdit,
+		orgMembers,
+		orgInvite,
+		orgRemove,
+		orgInvitations,
+		orgOutsideCollaborators,
+	},
+}
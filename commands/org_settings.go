@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var orgBasePermissions = []string{"none", "read", "write", "admin"}
+
+// yesNo formats an optional boolean setting. Settings only visible to
+// organization owners are absent for everyone else.
+func yesNo(v *bool) string {
+	if v == nil {
+		return "unknown"
+	}
+	if *v {
+		return "yes"
+	}
+	return "no"
+}
+
+var orgView = cli.Command{
+	Name:      "view",
+	Usage:     "Shows an organization's profile and settings",
+	ArgsUsage: "[org]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh org view [org]")
+		}
+		orgName := c.Args()[0]
+
+		orgLogger.Timing("One moment, please...")
+		org, err := utils.GetOrgDetails(orgName)
+		if err != nil {
+			return handleOrgError(err)
+		}
+		members, err := utils.GetAllUsersForOrg(orgName)
+		if err != nil {
+			return handleOrgError(err)
+		}
+
+		plan := "unknown"
+		if org.Plan != nil && org.Plan.Name != "" {
+			plan = org.Plan.Name
+			if org.Plan.Seats > 0 {
+				plan = fmt.Sprintf("%s (%d of %d seats in use)", plan, org.Plan.FilledSeats, org.Plan.Seats)
+			}
+		}
+		basePermission := org.DefaultRepositoryPermission
+		if basePermission == "" {
+			basePermission = "unknown"
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(org.Login)
+		fmt.Printf("%24s: %s\n", "Name", org.Name)
+		fmt.Printf("%24s: %s\n", "Description", org.Description)
+		fmt.Printf("%24s: %s\n", "Email", org.Email)
+		fmt.Printf("%24s: %s\n", "Location", org.Location)
+		fmt.Printf("%24s: %s\n", "URL", org.Blog)
+		fmt.Println("")
+		fmt.Printf("%24s: %s\n", "Plan", plan)
+		fmt.Printf("%24s: %s\n", "Billing email", org.BillingEmail)
+		fmt.Printf("%24s: %d\n", "Members", len(members))
+		fmt.Printf("%24s: %d\n", "Public repositories", org.PublicRepos)
+		fmt.Printf("%24s: %d\n", "Private repositories", org.Total_Private_Repos)
+		fmt.Println("")
+		fmt.Printf("%24s: %s\n", "Base permission", basePermission)
+		fmt.Printf("%24s: %s\n", "Two-factor required", yesNo(org.TwoFactorRequirementEnabled))
+		fmt.Printf("%24s: %s\n", "Members can create repos", yesNo(org.MembersCanCreateRepositories))
+		return nil
+	},
+}
+
+var orgEdit = cli.Command{
+	Name:      "edit",
+	Usage:     "Updates an organization's profile and settings",
+	ArgsUsage: "(--name NAME) (--description DESCRIPTION) (--email EMAIL) (--location LOCATION) (--url URL) (--billing-email EMAIL) (--default-permission none|read|write|admin) (--members-can-create-repos true|false) [org]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "display name of the organization",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "a short description of the organization",
+		},
+		cli.StringFlag{
+			Name:  "email",
+			Usage: "publicly visible email address",
+		},
+		cli.StringFlag{
+			Name:  "location",
+			Usage: "location of the organization",
+		},
+		cli.StringFlag{
+			Name:  "url",
+			Usage: "website of the organization",
+		},
+		cli.StringFlag{
+			Name:  "billing-email",
+			Usage: "billing email address, not publicly visible",
+		},
+		cli.StringFlag{
+			Name:  "default-permission",
+			Usage: "base permission of members on all repositories: 'none', 'read', 'write' or 'admin'",
+		},
+		cli.StringFlag{
+			Name:  "members-can-create-repos",
+			Usage: "whether members can create repositories: 'true' or 'false'",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh org edit (--name NAME) (--description DESCRIPTION) (--email EMAIL) (--location LOCATION) (--url URL) (--billing-email EMAIL) (--default-permission none|read|write|admin) (--members-can-create-repos true|false) [org]")
+		}
+		orgName := c.Args()[0]
+
+		params := octokit.M{}
+		fields := map[string]string{
+			"name":          "name",
+			"description":   "description",
+			"email":         "email",
+			"location":      "location",
+			"url":           "blog",
+			"billing-email": "billing_email",
+		}
+		for flag, field := range fields {
+			if c.IsSet(flag) {
+				params[field] = c.String(flag)
+			}
+		}
+		if c.IsSet("default-permission") {
+			permission := strings.ToLower(c.String("default-permission"))
+			valid := false
+			for _, p := range orgBasePermissions {
+				if p == permission {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return fmt.Errorf("when defining a default permission, please specify one of %s", strings.Join(orgBasePermissions, ", "))
+			}
+			params["default_repository_permission"] = permission
+		}
+		if c.IsSet("members-can-create-repos") {
+			allowed, err := strconv.ParseBool(c.String("members-can-create-repos"))
+			if err != nil {
+				return fmt.Errorf("--members-can-create-repos accepts either 'true' or 'false'")
+			}
+			params["members_can_create_repositories"] = allowed
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("nothing to change. Please provide at least one option")
+		}
+
+		orgLogger.Timing("One moment, please...")
+		var updated utils.OrgDetails
+		resp := utils.APIRequest("PATCH", &octokit.OrganizationURL, octokit.M{"org": orgName}, params, &updated)
+		utils.HandleClientError(resp, orgLogger)
+		orgLogger.Success("Updated %s", updated.Login)
+		return nil
+	},
+}
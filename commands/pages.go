@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var pagesLogger = utils.Logger.WithExtra("pages")
+
+func handlePagesError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		pagesLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// pagesSite returns the Pages site of a repository, exiting in case Pages is
+// not enabled for it
+func pagesSite(repoURL *utils.RepoURL) *utils.PagesSite {
+	site, resp := utils.GetPagesSite(repoURL)
+	if utils.IsNotFound(resp) {
+		pagesLogger.Warn("Pages is not enabled for %s", repoURL.ToURL())
+		os.Exit(1)
+	}
+	utils.HandleClientError(resp, pagesLogger)
+	return site
+}
+
+// buildDuration formats the duration of a build, given in milliseconds
+func buildDuration(b *octokit.PageBuild) string {
+	if b.Duration == 0 {
+		return ""
+	}
+	return (time.Duration(b.Duration) * time.Millisecond).String()
+}
+
+func buildError(b *octokit.PageBuild) string {
+	if b.Error == nil {
+		return ""
+	}
+	return b.Error.Message
+}
+
+func buildPusher(b *octokit.PageBuild) string {
+	if b.Pusher == nil {
+		return ""
+	}
+	return "@" + b.Pusher.Login
+}
+
+func buildCommit(b *octokit.PageBuild) string {
+	if len(b.Commit) > 7 {
+		return b.Commit[:7]
+	}
+	return b.Commit
+}
+
+var pagesStatus = cli.Command{
+	Name:      "status",
+	Usage:     "Shows a repository's Pages site along with its latest build",
+	ArgsUsage: "[repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh pages status [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		pagesLogger.Timing("One moment, please...")
+		site := pagesSite(&repoURL)
+		build, resp := utils.NewClient().Pages().PageBuildLatest(&octokit.PagesLatestBuildURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName})
+		if utils.IsNotFound(resp) {
+			build = nil
+		} else {
+			utils.HandleClientError(resp, pagesLogger)
+		}
+
+		source := "unknown"
+		if site.Source != nil {
+			source = site.Source.Branch + " " + site.Source.Path
+		}
+		custom404 := "no"
+		if site.Custom404 {
+			custom404 = "yes"
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		fmt.Printf("%12s: %s\n", "URL", site.HTMLURL)
+		fmt.Printf("%12s: %s\n", "Status", site.Status)
+		fmt.Printf("%12s: %s\n", "Source", source)
+		fmt.Printf("%12s: %s\n", "CNAME", site.Cname)
+		fmt.Printf("%12s: %s\n", "Custom 404", custom404)
+		fmt.Println("")
+		if build == nil {
+			fmt.Println("No builds")
+			return nil
+		}
+		created := ""
+		if build.CreatedAt != nil {
+			created = build.CreatedAt.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%12s: %s\n", "Latest build", build.Status)
+		fmt.Printf("%12s: %s\n", "Commit", buildCommit(build))
+		fmt.Printf("%12s: %s\n", "Pushed by", buildPusher(build))
+		fmt.Printf("%12s: %s\n", "Built on", created)
+		fmt.Printf("%12s: %s\n", "Duration", buildDuration(build))
+		if msg := buildError(build); msg != "" {
+			fmt.Printf("%12s: %s\n", "Error", msg)
+		}
+		return nil
+	},
+}
+
+var pagesBuilds = cli.Command{
+	Name:      "builds",
+	Usage:     "Lists builds of a repository's Pages site",
+	ArgsUsage: "(--limit N) [repository]",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "maximum number of builds to list. Use 0 to list all of them",
+			Value: 30,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh pages builds (--limit N) [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		pagesLogger.Timing("One moment, please...")
+		pagesSite(&repoURL)
+		builds, err := utils.GetPagesBuilds(&repoURL, c.Int("limit"))
+		if err != nil {
+			return handlePagesError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		if len(builds) == 0 {
+			fmt.Println("No builds")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Built on", "Status", "Commit", "Pushed by", "Duration", "Error"})
+		table.SetAutoFormatHeaders(true)
+		for _, b := range builds {
+			created := ""
+			if b.CreatedAt != nil {
+				created = b.CreatedAt.Format("2006-01-02 15:04")
+			}
+			table.Append([]string{created, b.Status, buildCommit(&b), buildPusher(&b), buildDuration(&b), buildError(&b)})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var pagesEnable = cli.Command{
+	Name:      "enable",
+	Usage:     "Enables a repository's Pages site, or changes its source",
+	ArgsUsage: "(--branch BRANCH) (--path /|/docs) [repository]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "branch",
+			Usage: "branch to publish. Defaults to the repository's default branch",
+		},
+		cli.StringFlag{
+			Name:  "path",
+			Usage: "directory to publish, either '/' or '/docs'",
+			Value: "/",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh pages enable (--branch BRANCH) (--path /|/docs) [repository]")
+		}
+		path := "/" + strings.Trim(c.String("path"), "/")
+		if path != "/" && path != "/docs" {
+			return fmt.Errorf("pages can only be published from either '/' or '/docs'")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		pagesLogger.Timing("One moment, please...")
+		branch := c.String("branch")
+		if branch == "" {
+			var err error
+			if branch, err = utils.GetDefaultBranch(&repoURL); err != nil {
+				return handlePagesError(err)
+			}
+		}
+		source := utils.PagesSource{Branch: branch, Path: path}
+		params := octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}
+
+		_, resp := utils.GetPagesSite(&repoURL)
+		if utils.IsNotFound(resp) {
+			var site utils.PagesSite
+			resp = utils.APIRequest("POST", &octokit.PagesURL, params, octokit.M{"source": source}, &site)
+			utils.HandleClientError(resp, pagesLogger)
+			pagesLogger.Success("Enabled Pages for %s from %s %s: %s", repoURL.ToURL(), branch, path, site.HTMLURL)
+			return nil
+		}
+		utils.HandleClientError(resp, pagesLogger)
+
+		resp = utils.APIRequest("PUT", &octokit.PagesURL, params, octokit.M{"source": source}, nil)
+		utils.HandleClientError(resp, pagesLogger)
+		pagesLogger.Success("Pages for %s is now published from %s %s", repoURL.ToURL(), branch, path)
+		return nil
+	},
+}
+
+var pagesDisable = cli.Command{
+	Name:      "disable",
+	Usage:     "Disables a repository's Pages site",
+	ArgsUsage: "[repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh pages disable [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		pagesLogger.Timing("One moment, please...")
+		site := pagesSite(&repoURL)
+		if !utils.Confirm(fmt.Sprintf("Disable Pages for %s? %s will no longer be available. y/[n]", repoURL.ToURL(), site.HTMLURL), false) {
+			return fmt.Errorf("aborting")
+		}
+		resp := utils.APIRequest("DELETE", &octokit.PagesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}, nil, nil)
+		utils.HandleClientError(resp, pagesLogger)
+		pagesLogger.Success("Disabled Pages for %s", repoURL.ToURL())
+		return nil
+	},
+}
+
+var pagesRebuild = cli.Command{
+	Name:      "rebuild",
+	Usage:     "Requests a new build of a repository's Pages site",
+	ArgsUsage: "[repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh pages rebuild [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		pagesLogger.Timing("One moment, please...")
+		pagesSite(&repoURL)
+		var build octokit.PageBuild
+		resp := utils.APIRequest("POST", &octokit.PagesBuildsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}, nil, &build)
+		utils.HandleClientError(resp, pagesLogger)
+		pagesLogger.Success("Requested a new build of %s (%s). Use gh pages status to follow it", repoURL.ToURL(), build.Status)
+		return nil
+	},
+}
+
+// Pages exposes GitHub Pages-related commands
+var Pages = cli.Command{
+	Name:  "pages",
+	Usage: "Manages GitHub Pages sites",
+	Subcommands: []cli.Command{
+		pagesStatus,
+		pagesBuilds,
+		pagesEnable,
+		pagesDisable,
+		pagesRebuild,
+	},
+}
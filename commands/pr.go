@@ -0,0 +1,521 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var prLogger = utils.Logger.WithExtra("pr")
+
+func handlePRError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		prLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// commitMessages returns subjects and bodies of commits reachable from head
+// but not from base, oldest first
+func commitMessages(base, head string) ([][2]string, error) {
+	out, err := utils.Git("log", "--reverse", "--format=%s%x1f%b%x1e", base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	messages := [][2]string{}
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+		parts := strings.SplitN(record, "\x1f", 2)
+		message := [2]string{strings.TrimSpace(parts[0]), ""}
+		if len(parts) == 2 {
+			message[1] = strings.TrimSpace(parts[1])
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// defaultPullRequestText suggests a title and body for a pull request based
+// on its commits. A single commit provides both; otherwise the branch name
+// becomes the title, and commit subjects are listed on the body.
+func defaultPullRequestText(branch string, commits [][2]string) (string, string) {
+	if len(commits) == 1 {
+		return commits[0][0], commits[0][1]
+	}
+	title := strings.NewReplacer("-", " ", "_", " ").Replace(branch)
+	if idx := strings.LastIndex(title, "/"); idx > -1 {
+		title = title[idx+1:]
+	}
+	title = utils.Capitalize(title)
+	lines := []string{}
+	for _, c := range commits {
+		lines = append(lines, "- "+c[0])
+	}
+	return title, strings.Join(lines, "\n")
+}
+
+var prList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists pull requests of a repository",
+	ArgsUsage: "(--state open|closed|all) (--base BRANCH) (--head [OWNER:]BRANCH) (--limit N) [repository]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "state",
+			Usage: "either 'open', 'closed' or 'all'",
+			Value: "open",
+		},
+		cli.StringFlag{
+			Name:  "base",
+			Usage: "only lists pull requests targeting a given branch",
+		},
+		cli.StringFlag{
+			Name:  "head",
+			Usage: "only lists pull requests from a given branch, in the form owner:branch",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "maximum number of pull requests to list. Use 0 to list all of them",
+			Value: 30,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh pr list (--state open|closed|all) (--base BRANCH) (--head [OWNER:]BRANCH) (--limit N) [repository]")
+		}
+		state := strings.ToLower(c.String("state"))
+		if state != "open" && state != "closed" && state != "all" {
+			return fmt.Errorf("when filtering by state, please specify either 'open', 'closed' or 'all'")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		filters := octokit.M{"state": state}
+		if base := c.String("base"); base != "" {
+			filters["base"] = base
+		}
+		if head := c.String("head"); head != "" {
+			if !strings.Contains(head, ":") {
+				head = repoURL.Username + ":" + head
+			}
+			filters["head"] = head
+		}
+
+		prLogger.Timing("One moment, please...")
+		pulls, err := utils.GetPullRequests(&repoURL, filters, c.Int("limit"))
+		if err != nil {
+			return handlePRError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		if len(pulls) == 0 {
+			fmt.Println("No pull requests")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"#", "State", "Title", "Author", "Branch", "Updated"})
+		table.SetAutoFormatHeaders(true)
+		for _, p := range pulls {
+			table.Append([]string{
+				strconv.Itoa(p.Number),
+				p.Status(),
+				p.Title,
+				"@" + p.User.Login,
+				fmt.Sprintf("%s → %s", p.Head.Label, p.Base.Ref),
+				p.UpdatedAt.Format("2006-01-02"),
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var prView = cli.Command{
+	Name:      "view",
+	Usage:     "Shows a pull request along with its reviewers and changed files",
+	ArgsUsage: "[repository] [number]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh pr view [repository] [number]")
+		}
+		repoURL, numbers, err := repoAndNumbers(c.Args())
+		if err != nil {
+			return err
+		}
+
+		prLogger.Timing("One moment, please...")
+		pull, resp := utils.GetPullRequest(&repoURL, numbers[0])
+		utils.HandleClientError(resp, prLogger)
+		reviews, err := utils.GetPullRequestReviews(&repoURL, pull.Number)
+		if err != nil {
+			return handlePRError(err)
+		}
+		files, err := utils.GetPullRequestFiles(&repoURL, pull.Number)
+		if err != nil {
+			return handlePRError(err)
+		}
+
+		// Only the latest review of each reviewer is relevant, and comments
+		// do not change a previous approval or request for changes.
+		reviewStates := map[string]string{}
+		reviewers := []string{}
+		for _, r := range reviews {
+			if _, seen := reviewStates[r.User.Login]; !seen {
+				reviewers = append(reviewers, r.User.Login)
+			} else if r.State == "COMMENTED" {
+				continue
+			}
+			reviewStates[r.User.Login] = strings.ToLower(strings.Replace(r.State, "_", " ", -1))
+		}
+		for _, u := range pull.RequestedReviewers {
+			if _, seen := reviewStates[u.Login]; !seen {
+				reviewers = append(reviewers, u.Login)
+			}
+			reviewStates[u.Login] = "pending"
+		}
+		reviewerList := []string{}
+		for _, login := range reviewers {
+			reviewerList = append(reviewerList, fmt.Sprintf("@%s (%s)", login, reviewStates[login]))
+		}
+		for _, t := range pull.RequestedTeams {
+			reviewerList = append(reviewerList, fmt.Sprintf("%s/%s (pending)", repoURL.Username, t.Slug))
+		}
+
+		mergeable := pull.MergeableState
+		if mergeable == "" {
+			mergeable = "unknown"
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Printf("%s #%d\n", pull.Title, pull.Number)
+		fmt.Printf("%s · @%s wants to merge %d commit(s) from %s into %s\n", pull.Status(), pull.User.Login, pull.Commits, pull.Head.Label, pull.Base.Ref)
+		fmt.Printf("  Changes: +%d -%d in %d file(s)\n", pull.Additions, pull.Deletions, pull.ChangedFiles)
+		if pull.State == "open" {
+			fmt.Printf("Mergeable: %s\n", mergeable)
+		}
+		if len(reviewerList) > 0 {
+			fmt.Printf("Reviewers: %s\n", strings.Join(reviewerList, ", "))
+		}
+		fmt.Printf("      URL: %s\n", pull.HTMLURL)
+		fmt.Println("")
+		if body := strings.TrimSpace(pull.Body); body != "" {
+			fmt.Println(body)
+		} else {
+			fmt.Println("No description provided.")
+		}
+
+		if len(files) > 0 {
+			fmt.Println("")
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"File", "Status", "+", "-"})
+			table.SetAutoFormatHeaders(true)
+			for _, f := range files {
+				table.Append([]string{f.Filename, f.Status, strconv.Itoa(f.Additions), strconv.Itoa(f.Deletions)})
+			}
+			table.Render()
+		}
+		return nil
+	},
+}
+
+var prCreate = cli.Command{
+	Name:      "create",
+	Usage:     "Opens a pull request from the current branch",
+	ArgsUsage: "(--base BRANCH) (--head [OWNER:]BRANCH) (--title TITLE) (--body TEXT|--body-file FILE) (--draft) ([repository])",
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "base",
+			Usage: "branch to merge changes into. Defaults to the repository's default branch",
+		},
+		cli.StringFlag{
+			Name:  "head",
+			Usage: "branch containing changes. Defaults to the branch tracked by the current one",
+		},
+		cli.StringFlag{
+			Name:  "title",
+			Usage: "title of the pull request",
+		},
+		cli.BoolFlag{
+			Name:  "draft",
+			Usage: "opens the pull request as a draft",
+		},
+	}, bodyFlags...),
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) > 1 {
+			return fmt.Errorf("usage: gh pr create (--base BRANCH) (--head [OWNER:]BRANCH) (--title TITLE) (--body TEXT|--body-file FILE) (--draft) ([repository])")
+		}
+
+		// Forks usually keep the original repository as "upstream"
+		var repoURL *utils.RepoURL
+		if len(c.Args()) == 1 {
+			r := utils.RepoURLFromString(c.Args()[0])
+			r.AutoComplete()
+			repoURL = &r
+		} else if r, err := utils.RepoURLFromRemote("upstream"); err == nil {
+			repoURL = r
+		} else if r, err := utils.RepoURLFromRemote("origin"); err == nil {
+			repoURL = r
+		} else {
+			return fmt.Errorf("could not determine the repository. Please provide one")
+		}
+
+		branch, err := utils.CurrentBranch()
+		if err != nil {
+			return err
+		}
+		head := c.String("head")
+		if head == "" {
+			upstream, err := utils.Git("rev-parse", "--abbrev-ref", branch+"@{upstream}")
+			if err != nil {
+				return fmt.Errorf("branch '%s' is not tracking a remote branch. Please push it first using git push -u origin %s", branch, branch)
+			}
+			split := strings.SplitN(upstream, "/", 2)
+			headRepo, err := utils.RepoURLFromRemote(split[0])
+			if err != nil {
+				return err
+			}
+			head = split[1]
+			if !strings.EqualFold(headRepo.Username, repoURL.Username) {
+				head = headRepo.Username + ":" + head
+			}
+			if count, err := utils.Git("rev-list", "--count", upstream+".."+branch); err == nil && count != "0" {
+				prLogger.Warn("%s has %s commit(s) not yet pushed to %s", branch, count, upstream)
+			}
+		}
+
+		prLogger.Timing("One moment, please...")
+		base := c.String("base")
+		if base == "" {
+			if base, err = utils.GetDefaultBranch(repoURL); err != nil {
+				return handlePRError(err)
+			}
+		}
+
+		commits := [][2]string{}
+		if remote, ok := utils.RemoteForRepo(repoURL); ok {
+			if commits, err = commitMessages(remote+"/"+base, branch); err != nil {
+				return err
+			}
+		}
+		title, body := defaultPullRequestText(branch, commits)
+		if c.IsSet("title") {
+			title = c.String("title")
+		}
+		switch {
+		case c.IsSet("body") || c.String("body-file") != "":
+			if body, err = readBody(c, false); err != nil {
+				return err
+			}
+		case !c.IsSet("title") && utils.IsInteractive():
+			// The first line becomes the title, just like commit messages
+			text, err := utils.EditText(title + "\n\n" + body)
+			if err != nil {
+				return err
+			}
+			parts := strings.SplitN(text, "\n", 2)
+			title, body = strings.TrimSpace(parts[0]), ""
+			if len(parts) == 2 {
+				body = strings.TrimSpace(parts[1])
+			}
+		}
+		if title == "" {
+			return fmt.Errorf("aborting due to an empty title")
+		}
+
+		params := octokit.M{"title": title, "body": body, "head": head, "base": base}
+		if c.Bool("draft") {
+			params["draft"] = true
+		}
+		var pull utils.PullRequest
+		resp := utils.APIRequest("POST", &octokit.PullRequestsURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}, params, &pull)
+		utils.HandleClientError(resp, prLogger)
+		prLogger.Success("Opened #%d: %s", pull.Number, pull.HTMLURL)
+		return nil
+	},
+}
+
+var prCheckout = cli.Command{
+	Name:      "checkout",
+	Usage:     "Checks out a pull request on a local branch",
+	ArgsUsage: "[repository] [number]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh pr checkout [repository] [number]")
+		}
+		repoURL, numbers, err := repoAndNumbers(c.Args())
+		if err != nil {
+			return err
+		}
+
+		prLogger.Timing("One moment, please...")
+		pull, resp := utils.GetPullRequest(&repoURL, numbers[0])
+		utils.HandleClientError(resp, prLogger)
+
+		remote, ok := utils.RemoteForRepo(&repoURL)
+		if !ok {
+			remote = fmt.Sprintf("https://github.com/%s.git", repoURL.ToURL())
+		}
+		// Branches coming from forks are prefixed with their owners to avoid
+		// clashing with local ones, such as master.
+		branch := pull.Head.Ref
+		if !strings.EqualFold(pull.Head.User.Login, repoURL.Username) {
+			branch = fmt.Sprintf("%s-%s", pull.Head.User.Login, pull.Head.Ref)
+		}
+
+		prLogger.Timing("Fetching #%d from %s", pull.Number, remote)
+		if _, err := utils.Git("fetch", remote, fmt.Sprintf("pull/%d/head", pull.Number)); err != nil {
+			return err
+		}
+		if _, err := utils.Git("rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+			if _, err := utils.Git("checkout", branch); err != nil {
+				return err
+			}
+			if _, err := utils.Git("merge", "--ff-only", "FETCH_HEAD"); err != nil {
+				return err
+			}
+		} else if _, err := utils.Git("checkout", "-b", branch, "FETCH_HEAD"); err != nil {
+			return err
+		}
+		prLogger.Success("Checked out #%d on %s", pull.Number, branch)
+		return nil
+	},
+}
+
+var prMerge = cli.Command{
+	Name:      "merge",
+	Usage:     "Merges a pull request",
+	ArgsUsage: "(--merge|--squash|--rebase) (--title TITLE) (--message MESSAGE) (--delete-branch) [repository] [number]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "merge",
+			Usage: "creates a merge commit. This is the default",
+		},
+		cli.BoolFlag{
+			Name:  "squash",
+			Usage: "squashes all commits into a single one",
+		},
+		cli.BoolFlag{
+			Name:  "rebase",
+			Usage: "rebases commits onto the base branch",
+		},
+		cli.StringFlag{
+			Name:  "title",
+			Usage: "title of the merge or squashed commit",
+		},
+		cli.StringFlag{
+			Name:  "message",
+			Usage: "message of the merge or squashed commit",
+		},
+		cli.BoolFlag{
+			Name:  "delete-branch",
+			Usage: "deletes the head branch once merged",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh pr merge (--merge|--squash|--rebase) (--title TITLE) (--message MESSAGE) (--delete-branch) [repository] [number]")
+		}
+		method := ""
+		for _, m := range []string{"merge", "squash", "rebase"} {
+			if c.Bool(m) {
+				if method != "" {
+					return fmt.Errorf("--merge, --squash and --rebase are mutually exclusive")
+				}
+				method = m
+			}
+		}
+		if method == "" {
+			method = "merge"
+		}
+		repoURL, numbers, err := repoAndNumbers(c.Args())
+		if err != nil {
+			return err
+		}
+
+		prLogger.Timing("One moment, please...")
+		pull, resp := utils.GetPullRequest(&repoURL, numbers[0])
+		utils.HandleClientError(resp, prLogger)
+		if pull.State != "open" {
+			return fmt.Errorf("#%d is %s", pull.Number, pull.Status())
+		}
+
+		fmt.Println("")
+		fmt.Printf("#%d: %s\n", pull.Number, pull.Title)
+		fmt.Printf("%s → %s\n", pull.Head.Label, pull.Base.Ref)
+		fmt.Println("")
+		if !utils.Confirm(fmt.Sprintf("Merge #%d into %s using %s? y/[n]", pull.Number, pull.Base.Ref, method), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		// Passing the head SHA prevents merging commits pushed in the meantime
+		params := octokit.M{"merge_method": method, "sha": pull.Head.Sha}
+		if c.IsSet("title") {
+			params["commit_title"] = c.String("title")
+		}
+		if c.IsSet("message") {
+			params["commit_message"] = c.String("message")
+		}
+		resp = utils.APIRequest("PUT", &utils.PullRequestMergeURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "number": pull.Number}, params, nil)
+		utils.HandleClientError(resp, prLogger)
+		prLogger.Success("Merged #%d into %s", pull.Number, pull.Base.Ref)
+
+		if c.Bool("delete-branch") {
+			if pull.Head.Repo == nil || !strings.EqualFold(pull.Head.Repo.FullName, repoURL.ToURL()) {
+				prLogger.Warn("%s belongs to another repository and was not deleted", pull.Head.Label)
+				return nil
+			}
+			resp = utils.APIRequest("DELETE", &utils.GitRefURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "ref": "heads/" + pull.Head.Ref}, nil, nil)
+			utils.HandleClientError(resp, prLogger)
+			prLogger.Success("Deleted %s", pull.Head.Ref)
+		}
+		return nil
+	},
+}
+
+var prDiff = cli.Command{
+	Name:      "diff",
+	Usage:     "Shows changes introduced by a pull request",
+	ArgsUsage: "[repository] [number]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh pr diff [repository] [number]")
+		}
+		repoURL, numbers, err := repoAndNumbers(c.Args())
+		if err != nil {
+			return err
+		}
+
+		diff, resp := utils.GetPullRequestDiff(&repoURL, numbers[0])
+		utils.HandleClientError(resp, prLogger)
+		defer diff.Close()
+		_, err = io.Copy(os.Stdout, diff)
+		return err
+	},
+}
+
+// PR exposes pull request-related commands
+var PR = cli.Command{
+	Name:    "pr",
+	Aliases: []string{"prs", "pulls"},
+	Usage:   "Manages pull requests",
+	Subcommands: []cli.Command{
+		prList,
+		prView,
+		prCreate,
+		prCheckout,
+		prMerge,
+		prDiff,
+	},
+}
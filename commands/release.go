@@ -0,0 +1,439 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var releaseLogger = utils.Logger.WithExtra("release")
+
+var notesFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "notes",
+		Usage: "release notes",
+	},
+	cli.StringFlag{
+		Name:  "notes-file",
+		Usage: "reads release notes from a file. Use '-' to read from the standard input",
+	},
+}
+
+func handleReleaseError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		releaseLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// releaseType describes whether a release is a draft, a pre-release or a
+// regular one
+func releaseType(r *octokit.Release) string {
+	switch {
+	case r.Draft:
+		return "draft"
+	case r.Prerelease:
+		return "pre-release"
+	}
+	return "release"
+}
+
+// humanizeBytes formats a size in bytes using binary units
+func humanizeBytes(size int) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := unit, 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+var releaseList = cli.Command{
+	Name:      "list",
+	Usage:     "Lists releases of a repository",
+	ArgsUsage: "[repository]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh release list [repository]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		releaseLogger.Timing("One moment, please...")
+		releases, err := utils.GetAllReleases(&repoURL)
+		if err != nil {
+			return handleReleaseError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(repoURL.ToURL())
+		if len(releases) == 0 {
+			fmt.Println("No releases")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Tag", "Name", "Type", "Published", "Assets"})
+		table.SetAutoFormatHeaders(true)
+		for _, r := range releases {
+			published := ""
+			if r.PublishedAt != nil {
+				published = r.PublishedAt.Format("2006-01-02")
+			}
+			table.Append([]string{r.TagName, r.Name, releaseType(&r), published, strconv.Itoa(len(r.Assets))})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var releaseView = cli.Command{
+	Name:      "view",
+	Usage:     "Shows a release along with its assets",
+	ArgsUsage: "[repository] [tag|latest]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh release view [repository] [tag|latest]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		releaseLogger.Timing("One moment, please...")
+		release, resp := utils.GetRelease(&repoURL, c.Args()[1])
+		utils.HandleClientError(resp, releaseLogger)
+
+		published := "not published"
+		if release.PublishedAt != nil {
+			published = "published on " + release.PublishedAt.Format("2006-01-02")
+		}
+		name := release.Name
+		if name == "" {
+			name = release.TagName
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(name)
+		fmt.Printf("%s · %s · %s\n", release.TagName, releaseType(release), published)
+		fmt.Printf("URL: %s\n", release.HTMLURL)
+		fmt.Println("")
+		if body := strings.TrimSpace(release.Body); body != "" {
+			fmt.Println(body)
+		} else {
+			fmt.Println("No release notes provided.")
+		}
+
+		if len(release.Assets) > 0 {
+			fmt.Println("")
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Asset", "Size", "Downloads"})
+			table.SetAutoFormatHeaders(true)
+			for _, a := range release.Assets {
+				table.Append([]string{a.Name, humanizeBytes(a.Size), strconv.Itoa(a.DownloadCount)})
+			}
+			table.Render()
+		}
+		return nil
+	},
+}
+
+var releaseCreate = cli.Command{
+	Name:      "create",
+	Usage:     "Creates a release, optionally uploading assets to it",
+	ArgsUsage: "(--title TITLE) (--target BRANCH|SHA) (--notes TEXT|--notes-file FILE) (--notes-from-commits) (--draft) (--prerelease) [repository] [tag] [asset...]",
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "title",
+			Usage: "title of the release. Defaults to the tag name",
+		},
+		cli.StringFlag{
+			Name:  "target",
+			Usage: "branch or commit the tag is created from, when it does not exist yet",
+		},
+		cli.BoolFlag{
+			Name:  "notes-from-commits",
+			Usage: "generates notes from changes made since the latest release",
+		},
+		cli.BoolFlag{
+			Name:  "draft",
+			Usage: "keeps the release as an unpublished draft",
+		},
+		cli.BoolFlag{
+			Name:  "prerelease",
+			Usage: "marks the release as a pre-release",
+		},
+	}, notesFlags...),
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 2 {
+			return fmt.Errorf("usage: gh release create (--title TITLE) (--target BRANCH|SHA) (--notes TEXT|--notes-file FILE) (--notes-from-commits) (--draft) (--prerelease) [repository] [tag] [asset...]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+		tag := c.Args()[1]
+		assets := c.Args()[2:]
+		for _, path := range assets {
+			if stat, err := os.Stat(path); err != nil {
+				return err
+			} else if stat.IsDir() {
+				return fmt.Errorf("%s is a directory", path)
+			}
+		}
+		notes, _, err := readFlagText(c, "notes")
+		if err != nil {
+			return err
+		}
+		if c.Bool("notes-from-commits") {
+			releaseLogger.Timing("Generating release notes")
+			generated, err := notesFromCommits(&repoURL, tag, c.String("target"))
+			if err != nil {
+				return handleReleaseError(err)
+			}
+			notes = strings.TrimSpace(notes + "\n\n" + generated)
+		}
+
+		// Releases are created as drafts and only published once all assets
+		// are uploaded, so nobody gets to see an incomplete release.
+		params := octokit.ReleaseParams{
+			TagName:         tag,
+			TargetCommitish: c.String("target"),
+			Name:            c.String("title"),
+			Body:            strings.TrimSpace(notes),
+			Draft:           true,
+			Prerelease:      c.Bool("prerelease"),
+		}
+		releaseLogger.Timing("Creating release %s", tag)
+		var release octokit.Release
+		resp := utils.APIRequest("POST", &octokit.ReleasesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName}, params, &release)
+		utils.HandleClientError(resp, releaseLogger)
+
+		for _, path := range assets {
+			releaseLogger.Timing("Uploading %s", filepath.Base(path))
+			resp := utils.UploadReleaseAsset(&release, path)
+			utils.HandleClientError(resp, releaseLogger)
+		}
+
+		if !c.Bool("draft") {
+			releaseLogger.Timing("Publishing release %s", tag)
+			resp = utils.APIRequest("PATCH", &octokit.ReleasesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "id": release.ID}, octokit.M{"draft": false}, &release)
+			utils.HandleClientError(resp, releaseLogger)
+		}
+		releaseLogger.Success("Created %s %s with %d asset(s): %s", releaseType(&release), tag, len(assets), release.HTMLURL)
+		return nil
+	},
+}
+
+var releaseEdit = cli.Command{
+	Name:      "edit",
+	Usage:     "Updates a release",
+	ArgsUsage: "(--tag TAG) (--title TITLE) (--target BRANCH|SHA) (--notes TEXT|--notes-file FILE) (--draft true|false) (--prerelease true|false) [repository] [tag]",
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "tag",
+			Usage: "a new tag for the release",
+		},
+		cli.StringFlag{
+			Name:  "title",
+			Usage: "a new title for the release",
+		},
+		cli.StringFlag{
+			Name:  "target",
+			Usage: "branch or commit the tag is created from, when it does not exist yet",
+		},
+		cli.StringFlag{
+			Name:  "draft",
+			Usage: "whether the release is an unpublished draft: 'true' or 'false'",
+		},
+		cli.StringFlag{
+			Name:  "prerelease",
+			Usage: "whether the release is a pre-release: 'true' or 'false'",
+		},
+	}, notesFlags...),
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh release edit (--tag TAG) (--title TITLE) (--target BRANCH|SHA) (--notes TEXT|--notes-file FILE) (--draft true|false) (--prerelease true|false) [repository] [tag]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		params := octokit.M{}
+		fields := map[string]string{
+			"tag":    "tag_name",
+			"title":  "name",
+			"target": "target_commitish",
+		}
+		for flag, field := range fields {
+			if c.IsSet(flag) {
+				params[field] = c.String(flag)
+			}
+		}
+		for _, flag := range []string{"draft", "prerelease"} {
+			if c.IsSet(flag) {
+				v, err := strconv.ParseBool(c.String(flag))
+				if err != nil {
+					return fmt.Errorf("--%s accepts either 'true' or 'false'", flag)
+				}
+				params[flag] = v
+			}
+		}
+		notes, ok, err := readFlagText(c, "notes")
+		if err != nil {
+			return err
+		}
+		if ok {
+			params["body"] = strings.TrimSpace(notes)
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("nothing to change. Please provide at least one option")
+		}
+
+		releaseLogger.Timing("One moment, please...")
+		release, resp := utils.GetRelease(&repoURL, c.Args()[1])
+		utils.HandleClientError(resp, releaseLogger)
+		var updated octokit.Release
+		resp = utils.APIRequest("PATCH", &octokit.ReleasesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "id": release.ID}, params, &updated)
+		utils.HandleClientError(resp, releaseLogger)
+		releaseLogger.Success("Updated %s", updated.TagName)
+		return nil
+	},
+}
+
+var releaseDelete = cli.Command{
+	Name:      "delete",
+	Usage:     "Deletes a release",
+	ArgsUsage: "(--cleanup-tag) [repository] [tag]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "cleanup-tag",
+			Usage: "also deletes the release's tag",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh release delete (--cleanup-tag) [repository] [tag]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+
+		releaseLogger.Timing("One moment, please...")
+		release, resp := utils.GetRelease(&repoURL, c.Args()[1])
+		utils.HandleClientError(resp, releaseLogger)
+
+		question := fmt.Sprintf("Delete release %s of %s? y/[n]", release.TagName, repoURL.ToURL())
+		if c.Bool("cleanup-tag") {
+			question = fmt.Sprintf("Delete release and tag %s of %s? y/[n]", release.TagName, repoURL.ToURL())
+		}
+		if !utils.Confirm(question, false) {
+			return fmt.Errorf("aborting")
+		}
+
+		resp = utils.APIRequest("DELETE", &octokit.ReleasesURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "id": release.ID}, nil, nil)
+		utils.HandleClientError(resp, releaseLogger)
+		if c.Bool("cleanup-tag") {
+			resp = utils.APIRequest("DELETE", &utils.GitRefURL, octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "ref": "tags/" + release.TagName}, nil, nil)
+			utils.HandleClientError(resp, releaseLogger)
+		}
+		releaseLogger.Success("Deleted release %s", release.TagName)
+		return nil
+	},
+}
+
+var releaseDownload = cli.Command{
+	Name:      "download",
+	Usage:     "Downloads assets of a release",
+	ArgsUsage: "(--pattern GLOB...) (--dir DIRECTORY) [repository] [tag|latest]",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "pattern",
+			Usage: "only downloads assets matching a glob pattern. May be repeated",
+		},
+		cli.StringFlag{
+			Name:  "dir",
+			Usage: "directory to download assets into",
+			Value: ".",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh release download (--pattern GLOB...) (--dir DIRECTORY) [repository] [tag|latest]")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+		patterns := c.StringSlice("pattern")
+		for _, p := range patterns {
+			if _, err := filepath.Match(p, ""); err != nil {
+				return fmt.Errorf("invalid pattern '%s'", p)
+			}
+		}
+
+		releaseLogger.Timing("One moment, please...")
+		release, resp := utils.GetRelease(&repoURL, c.Args()[1])
+		utils.HandleClientError(resp, releaseLogger)
+
+		assets := []octokit.Asset{}
+		for _, a := range release.Assets {
+			matches := len(patterns) == 0
+			for _, p := range patterns {
+				if ok, _ := filepath.Match(p, a.Name); ok {
+					matches = true
+					break
+				}
+			}
+			if matches {
+				assets = append(assets, a)
+			}
+		}
+		if len(assets) == 0 {
+			releaseLogger.Warn("No assets to download from %s", release.TagName)
+			os.Exit(1)
+		}
+
+		if err := os.MkdirAll(c.String("dir"), 0755); err != nil {
+			return err
+		}
+		for _, a := range assets {
+			path := filepath.Join(c.String("dir"), a.Name)
+			releaseLogger.Timing("Downloading %s (%s)", a.Name, humanizeBytes(a.Size))
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			err = utils.DownloadReleaseAsset(&a, f)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return err
+			}
+		}
+		releaseLogger.Success("Downloaded %d asset(s) from %s", len(assets), release.TagName)
+		return nil
+	},
+}
+
+// Release exposes release-related commands
+var Release = cli.Command{
+	Name:    "release",
+	Aliases: []string{"releases"},
+	Usage:   "Manages repository releases",
+	Subcommands: []cli.Command{
+		releaseList,
+		releaseView,
+		releaseNotes,
+		releaseCreate,
+		releaseEdit,
+		releaseDelete,
+		releaseDownload,
+	},
+}
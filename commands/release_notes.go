@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+)
+
+var (
+	conventionalCommitPattern = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?(!)?:\s*(.+)$`)
+	mergedPullPattern         = regexp.MustCompile(`^Merge pull request #(\d+) from `)
+	squashedPullPattern       = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+)
+
+// notesSections lists release notes sections in the order they are printed
+var notesSections = []string{
+	"Breaking changes",
+	"Features",
+	"Bug fixes",
+	"Performance",
+	"Documentation",
+	"Refactoring",
+	"Tests",
+	"Build",
+	"Chores",
+	"Other changes",
+}
+
+// commitTypeSections maps conventional commit types to sections
+var commitTypeSections = map[string]string{
+	"feat":     "Features",
+	"feature":  "Features",
+	"fix":      "Bug fixes",
+	"perf":     "Performance",
+	"docs":     "Documentation",
+	"refactor": "Refactoring",
+	"test":     "Tests",
+	"tests":    "Tests",
+	"build":    "Build",
+	"ci":       "Build",
+	"chore":    "Chores",
+}
+
+// labelSections maps pull request labels to sections, used when commits do
+// not follow conventional commits
+var labelSections = map[string]string{
+	"breaking":         "Breaking changes",
+	"breaking change":  "Breaking changes",
+	"enhancement":      "Features",
+	"feature":          "Features",
+	"bug":              "Bug fixes",
+	"bugfix":           "Bug fixes",
+	"performance":      "Performance",
+	"documentation":    "Documentation",
+	"docs":             "Documentation",
+	"refactor":         "Refactoring",
+	"tests":            "Tests",
+	"dependencies":     "Build",
+	"ci":               "Build",
+	"chore":            "Chores",
+	"internal":         "Chores",
+	"skip-changelog":   "",
+	"skip changelog":   "",
+	"no-release-notes": "",
+}
+
+// generateReleaseNotes generates release notes in Markdown from commits made
+// between two refs, grouping them by conventional commit type or pull request
+// labels
+func generateReleaseNotes(repoURL *utils.RepoURL, from, to string) (string, error) {
+	commits, err := utils.GetCommitsBetween(repoURL, from, to)
+	if err != nil {
+		return "", err
+	}
+
+	entries := map[string][]string{}
+	pulls := map[int]*utils.Issue{}
+	// Commits are listed newest first, but notes read better in order
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		lines := strings.SplitN(strings.TrimSpace(c.Commit.Message), "\n", 2)
+		subject := strings.TrimSpace(lines[0])
+		author := ""
+		if c.Author != nil {
+			author = c.Author.Login
+		}
+
+		number := 0
+		if m := mergedPullPattern.FindStringSubmatch(subject); m != nil {
+			number, _ = strconv.Atoi(m[1])
+			// Merge commits carry the pull request title on their body
+			subject = ""
+			if len(lines) == 2 {
+				subject = strings.TrimSpace(strings.SplitN(strings.TrimSpace(lines[1]), "\n", 2)[0])
+			}
+		} else if m := squashedPullPattern.FindStringSubmatch(subject); m != nil {
+			number, _ = strconv.Atoi(m[1])
+			subject = strings.TrimSuffix(subject, m[0])
+		} else if len(c.Parents) > 1 {
+			// Other merges, such as merged branches, add nothing to notes
+			continue
+		}
+
+		section := ""
+		if m := conventionalCommitPattern.FindStringSubmatch(subject); m != nil {
+			if s, ok := commitTypeSections[strings.ToLower(m[1])]; ok {
+				section = s
+				subject = m[3]
+				if m[2] == "!" {
+					section = "Breaking changes"
+				}
+			}
+		}
+
+		if number > 0 {
+			pull, ok := pulls[number]
+			if !ok {
+				issue, resp := utils.GetIssue(repoURL, number)
+				if resp.HasError() {
+					return "", resp.Err
+				}
+				pulls[number] = issue
+				pull = issue
+			}
+			if pull.User.Login != "" {
+				author = pull.User.Login
+			}
+			if subject == "" {
+				subject = pull.Title
+			}
+			skip := false
+			for _, l := range pull.Labels {
+				s, ok := labelSections[strings.ToLower(l.Name)]
+				if !ok {
+					continue
+				}
+				if s == "" {
+					skip = true
+					break
+				}
+				if section == "" || s == "Breaking changes" {
+					section = s
+				}
+			}
+			if skip {
+				continue
+			}
+		}
+		if section == "" {
+			section = "Other changes"
+		}
+		subject = utils.Capitalize(subject)
+
+		entry := "- " + subject
+		if number > 0 {
+			entry += fmt.Sprintf(" (#%d)", number)
+		} else {
+			entry += fmt.Sprintf(" (%s)", c.Sha[:7])
+		}
+		if author != "" {
+			entry += " @" + author
+		}
+		entries[section] = append(entries[section], entry)
+	}
+
+	notes := []string{}
+	for _, section := range notesSections {
+		if len(entries[section]) == 0 {
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("## %s\n\n%s", section, strings.Join(entries[section], "\n")))
+	}
+	if len(notes) == 0 {
+		return "", fmt.Errorf("no changes between %s and %s", from, to)
+	}
+	return strings.Join(notes, "\n\n"), nil
+}
+
+var releaseNotes = cli.Command{
+	Name:      "notes",
+	Usage:     "Generates release notes from commits and merged pull requests",
+	ArgsUsage: "[repository] [from-tag]..([to-ref])",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 || !strings.Contains(c.Args()[1], "..") {
+			return fmt.Errorf("usage: gh release notes [repository] [from-tag]..([to-ref])")
+		}
+		repoURL := utils.RepoURLFromString(c.Args()[0])
+		repoURL.AutoComplete()
+		refs := strings.SplitN(c.Args()[1], "..", 2)
+		from, to := refs[0], refs[1]
+		if from == "" {
+			return fmt.Errorf("a starting tag is required")
+		}
+
+		releaseLogger.Timing("One moment, please...")
+		if to == "" {
+			var err error
+			if to, err = utils.GetDefaultBranch(&repoURL); err != nil {
+				return handleReleaseError(err)
+			}
+		}
+		notes, err := generateReleaseNotes(&repoURL, from, to)
+		if err != nil {
+			return handleReleaseError(err)
+		}
+		fmt.Println(notes)
+		return nil
+	},
+}
+
+// notesFromCommits generates notes for a release being created under a
+// given tag, covering changes since the latest published release
+func notesFromCommits(repoURL *utils.RepoURL, tag, target string) (string, error) {
+	latest, resp := utils.GetRelease(repoURL, "latest")
+	if utils.IsNotFound(resp) {
+		return "", fmt.Errorf("%s has no published releases to generate notes from", repoURL.ToURL())
+	}
+	if resp.HasError() {
+		return "", resp.Err
+	}
+
+	// The tag may already be pushed; otherwise it will be created from the
+	// target, or the default branch.
+	to := tag
+	if _, resp := utils.ResolveRef(repoURL, tag); resp.HasError() {
+		if !utils.IsNotFound(resp) && !utils.IsUnprocessable(resp) {
+			return "", resp.Err
+		}
+		to = target
+		if to == "" {
+			var err error
+			if to, err = utils.GetDefaultBranch(repoURL); err != nil {
+				return "", err
+			}
+		}
+	}
+	return generateReleaseNotes(repoURL, latest.TagName, to)
+}
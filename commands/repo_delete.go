@@ -1,10 +1,7 @@
 package commands
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/urfave/cli"
 	"github.com/victorgama/gh/utils"
@@ -31,16 +28,7 @@ var RmRepo = cli.Command{
 
 		fmt.Println("Hey! You're about to perform a really dangerous action.")
 		fmt.Printf("To confirm you really want to delete %s, please enter its name again:\n", repo.FullName)
-		fmt.Print("What is its name again? ")
-		reader := bufio.NewReader(os.Stdin)
-		s, err := reader.ReadString('\n')
-		if err != nil {
-			panic(err)
-		}
-
-		s = strings.TrimSpace(s)
-		s = strings.ToLower(s)
-		if s != strings.ToLower(repo.Name) {
+		if !utils.ConfirmName("What is its name again? ", repo.Name) {
 			fmt.Println("Nope. That's not its name. Aborting.")
 			return fmt.Errorf("aborted")
 		}
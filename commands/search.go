@@ -0,0 +1,317 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var searchLogger = utils.Logger.WithExtra("search")
+
+var searchFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "org",
+		Usage: "only searches within an organization or user",
+	},
+	cli.StringFlag{
+		Name:  "language",
+		Usage: "only searches for a given programming language",
+	},
+	cli.StringFlag{
+		Name:  "state",
+		Usage: "only searches for issues either 'open' or 'closed'",
+	},
+	cli.StringSliceFlag{
+		Name:  "label",
+		Usage: "only searches for issues with a given label. May be repeated",
+	},
+	cli.StringFlag{
+		Name:  "sort",
+		Usage: "field to sort results by. Defaults to best match",
+	},
+	cli.StringFlag{
+		Name:  "order",
+		Usage: "either 'asc' or 'desc'",
+	},
+	cli.IntFlag{
+		Name:  "limit",
+		Usage: "maximum number of results to list. Use 0 to list all of them, up to 1000",
+		Value: 30,
+	},
+	cli.BoolFlag{
+		Name:  "json",
+		Usage: "prints results as JSON",
+	},
+}
+
+// qualifier formats a search qualifier, quoting values containing spaces
+func qualifier(name, value string) string {
+	if strings.ContainsAny(value, " \t") {
+		value = strconv.Quote(value)
+	}
+	return name + ":" + value
+}
+
+// searchQuery builds a query from the given arguments and qualifier flags,
+// rejecting flags that do not apply to the kind of search being made
+func searchQuery(c *cli.Context, allowed ...string) (string, error) {
+	usage := fmt.Errorf("usage: gh search %s (--org ORG) (--language LANGUAGE) (--state open|closed) (--label LABEL...) (--sort FIELD) (--order asc|desc) (--limit N) (--json) [query...]", c.Command.Name)
+	if len(c.Args()) == 0 {
+		return "", usage
+	}
+	for _, name := range []string{"org", "language", "state", "label"} {
+		if !c.IsSet(name) {
+			continue
+		}
+		ok := false
+		for _, a := range allowed {
+			ok = ok || a == name
+		}
+		if !ok {
+			return "", fmt.Errorf("--%s does not apply to %s searches", name, c.Command.Name)
+		}
+	}
+	if order := c.String("order"); order != "" && order != "asc" && order != "desc" {
+		return "", fmt.Errorf("when ordering results, please specify either 'asc' or 'desc'")
+	}
+
+	terms := []string{strings.Join(c.Args(), " ")}
+	if org := c.String("org"); org != "" {
+		terms = append(terms, qualifier("user", org))
+	}
+	if language := c.String("language"); language != "" {
+		terms = append(terms, qualifier("language", language))
+	}
+	if state := strings.ToLower(c.String("state")); state != "" {
+		if state != "open" && state != "closed" {
+			return "", fmt.Errorf("when filtering by state, please specify either 'open' or 'closed'")
+		}
+		terms = append(terms, qualifier("state", state))
+	}
+	for _, label := range c.StringSlice("label") {
+		terms = append(terms, qualifier("label", label))
+	}
+	return strings.Join(terms, " "), nil
+}
+
+func handleSearchError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		searchLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// printSearchJSON prints search results as indented JSON
+func printSearchJSON(items interface{}) error {
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// printSearchHeading prints the query and how many of its results are shown
+func printSearchHeading(query string, shown, total int) {
+	fmt.Println("")
+	color.New(color.Bold, color.Underline).Println(query)
+	if shown < total {
+		fmt.Printf("Showing %d of %d result(s)\n", shown, total)
+	} else {
+		fmt.Printf("%d result(s)\n", total)
+	}
+}
+
+// issueRepository extracts the repository an issue belongs to from its URL
+func issueRepository(i *octokit.Issue) string {
+	parts := strings.Split(strings.TrimPrefix(i.HTMLURL, "https://github.com/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+var searchRepos = cli.Command{
+	Name:      "repos",
+	Usage:     "Searches for repositories",
+	ArgsUsage: "(--org ORG) (--language LANGUAGE) (--sort stars|forks|updated) (--order asc|desc) (--limit N) (--json) [query...]",
+	Flags:     searchFlags,
+	Action: func(c *cli.Context) error {
+		query, err := searchQuery(c, "org", "language")
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("json") {
+			searchLogger.Timing("Searching...")
+		}
+		repos, total, err := utils.SearchRepositories(query, c.String("sort"), c.String("order"), c.Int("limit"))
+		if err != nil {
+			return handleSearchError(err)
+		}
+		if c.Bool("json") {
+			return printSearchJSON(repos)
+		}
+
+		printSearchHeading(query, len(repos), total)
+		if len(repos) == 0 {
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Description", "Language", "★", "Updated"})
+		table.SetAutoFormatHeaders(true)
+		for _, r := range repos {
+			updated := ""
+			if r.UpdatedAt != nil {
+				updated = r.UpdatedAt.Format("2006-01-02")
+			}
+			table.Append([]string{r.FullName, r.Description, r.Language, strconv.Itoa(r.StargazersCount), updated})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var searchIssues = cli.Command{
+	Name:      "issues",
+	Usage:     "Searches for issues and pull requests",
+	ArgsUsage: "(--org ORG) (--language LANGUAGE) (--state open|closed) (--label LABEL...) (--sort comments|created|updated) (--order asc|desc) (--limit N) (--json) [query...]",
+	Flags:     searchFlags,
+	Action: func(c *cli.Context) error {
+		query, err := searchQuery(c, "org", "language", "state", "label")
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("json") {
+			searchLogger.Timing("Searching...")
+		}
+		issues, total, err := utils.SearchIssues(query, c.String("sort"), c.String("order"), c.Int("limit"))
+		if err != nil {
+			return handleSearchError(err)
+		}
+		if c.Bool("json") {
+			return printSearchJSON(issues)
+		}
+
+		printSearchHeading(query, len(issues), total)
+		if len(issues) == 0 {
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Repository", "#", "Type", "State", "Title", "Author", "Updated"})
+		table.SetAutoFormatHeaders(true)
+		for _, i := range issues {
+			kind := "issue"
+			if i.PullRequest.HTMLURL != "" {
+				kind = "pull request"
+			}
+			table.Append([]string{
+				issueRepository(&i),
+				strconv.Itoa(i.Number),
+				kind,
+				i.State,
+				i.Title,
+				"@" + i.User.Login,
+				i.UpdatedAt.Format("2006-01-02"),
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var searchCode = cli.Command{
+	Name:      "code",
+	Usage:     "Searches for code",
+	ArgsUsage: "(--org ORG) (--language LANGUAGE) (--sort indexed) (--order asc|desc) (--limit N) (--json) [query...]",
+	Flags:     searchFlags,
+	Action: func(c *cli.Context) error {
+		query, err := searchQuery(c, "org", "language")
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("json") {
+			searchLogger.Timing("Searching...")
+		}
+		files, total, err := utils.SearchCode(query, c.String("sort"), c.String("order"), c.Int("limit"))
+		if err != nil {
+			return handleSearchError(err)
+		}
+		if c.Bool("json") {
+			return printSearchJSON(files)
+		}
+
+		printSearchHeading(query, len(files), total)
+		if len(files) == 0 {
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Repository", "Path", "URL"})
+		table.SetAutoFormatHeaders(true)
+		for _, f := range files {
+			table.Append([]string{f.Repository.FullName, f.Path, string(f.HTMLURL)})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var searchUsers = cli.Command{
+	Name:      "users",
+	Usage:     "Searches for users and organizations",
+	ArgsUsage: "(--language LANGUAGE) (--sort followers|repositories|joined) (--order asc|desc) (--limit N) (--json) [query...]",
+	Flags:     searchFlags,
+	Action: func(c *cli.Context) error {
+		query, err := searchQuery(c, "language")
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("json") {
+			searchLogger.Timing("Searching...")
+		}
+		users, total, err := utils.SearchUsers(query, c.String("sort"), c.String("order"), c.Int("limit"))
+		if err != nil {
+			return handleSearchError(err)
+		}
+		if c.Bool("json") {
+			return printSearchJSON(users)
+		}
+
+		printSearchHeading(query, len(users), total)
+		if len(users) == 0 {
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Username", "Type", "URL"})
+		table.SetAutoFormatHeaders(true)
+		for _, u := range users {
+			table.Append([]string{"@" + u.Login, u.Type, u.HTMLURL})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+// Search exposes search-related commands
+var Search = cli.Command{
+	Name:  "search",
+	Usage: "Searches for repositories, issues, code and users",
+	Subcommands: []cli.Command{
+		searchRepos,
+		searchIssues,
+		searchCode,
+		searchUsers,
+	},
+}
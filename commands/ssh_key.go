@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var sshKeyLogger = utils.Logger.WithExtra("ssh-key")
+
+func handleSSHKeyError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		sshKeyLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// keyFingerprint returns the fingerprint of a registered key, or an empty
+// string in case it cannot be parsed
+func keyFingerprint(k *octokit.Key) string {
+	key, err := utils.ParseSSHPublicKey(k.Key)
+	if err != nil {
+		return ""
+	}
+	return key.Fingerprint()
+}
+
+func keyType(k *octokit.Key) string {
+	if fields := strings.Fields(k.Key); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// defaultSSHKeyPath picks a key from ~/.ssh/id_*.pub, asking the user to
+// choose one when there are several of them
+func defaultSSHKeyPath() (string, error) {
+	all, err := utils.LocalSSHPublicKeys()
+	if err != nil {
+		return "", err
+	}
+	paths := []string{}
+	for _, p := range all {
+		if strings.HasPrefix(filepath.Base(p), "id_") {
+			paths = append(paths, p)
+		}
+	}
+	switch {
+	case len(paths) == 0:
+		return "", fmt.Errorf("no public keys found at ~/.ssh/id_*.pub. Please provide one")
+	case len(paths) == 1:
+		return paths[0], nil
+	case !utils.IsInteractive():
+		return "", fmt.Errorf("found %d public keys at ~/.ssh. Please provide one", len(paths))
+	}
+	idx := utils.Choose("Which key should be added?", paths)
+	if idx == -1 {
+		return "", fmt.Errorf("aborting")
+	}
+	return paths[idx], nil
+}
+
+var sshKeyList = cli.Command{
+	Name:  "list",
+	Usage: "Lists SSH keys of the authenticated user",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 0 {
+			return fmt.Errorf("usage: gh ssh-key list")
+		}
+
+		sshKeyLogger.Timing("One moment, please...")
+		keys, err := utils.GetCurrentUserPublicKeys()
+		if err != nil {
+			return handleSSHKeyError(err)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println("SSH keys")
+		if len(keys) == 0 {
+			fmt.Println("No SSH keys")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Title", "Type", "Fingerprint", "Added"})
+		table.SetAutoFormatHeaders(true)
+		for _, k := range keys {
+			added := ""
+			if k.CreatedAt != nil {
+				added = k.CreatedAt.Format("2006-01-02")
+			}
+			table.Append([]string{strconv.Itoa(k.Id), k.Title, keyType(&k), keyFingerprint(&k), added})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var sshKeyAdd = cli.Command{
+	Name:      "add",
+	Usage:     "Adds an SSH key to the authenticated user",
+	ArgsUsage: "(--title TITLE) ([file])",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "title",
+			Usage: "title of the key. Defaults to the key's comment, or the hostname",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) > 1 {
+			return fmt.Errorf("usage: gh ssh-key add (--title TITLE) ([file])")
+		}
+		var path string
+		var err error
+		if len(c.Args()) == 1 {
+			path = c.Args()[0]
+		} else if path, err = defaultSSHKeyPath(); err != nil {
+			return err
+		}
+		key, err := utils.ReadSSHPublicKey(path)
+		if err != nil {
+			return err
+		}
+		title := c.String("title")
+		if title == "" {
+			title = key.Comment
+		}
+		if title == "" {
+			if title, err = os.Hostname(); err != nil {
+				return fmt.Errorf("please provide a title using --title")
+			}
+		}
+
+		sshKeyLogger.Timing("One moment, please...")
+		keys, err := utils.GetCurrentUserPublicKeys()
+		if err != nil {
+			return handleSSHKeyError(err)
+		}
+		for _, k := range keys {
+			if registered, err := utils.ParseSSHPublicKey(k.Key); err == nil && registered.Equal(key) {
+				sshKeyLogger.Info("%s is already registered as %s (%d)", path, k.Title, k.Id)
+				return nil
+			}
+		}
+
+		created, resp := utils.NewClient().PublicKeys().Create(&octokit.CurrentPublicKeyUrl, nil, octokit.M{"title": title, "key": key.String()})
+		utils.HandleClientError(resp, sshKeyLogger)
+		sshKeyLogger.Success("Added %s as %s (%d): %s", path, created.Title, created.Id, key.Fingerprint())
+		return nil
+	},
+}
+
+var sshKeyRm = cli.Command{
+	Name:      "rm",
+	Aliases:   []string{"delete"},
+	Usage:     "Removes one or more SSH keys from the authenticated user",
+	ArgsUsage: "[id|title...]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 1 {
+			return fmt.Errorf("usage: gh ssh-key rm [id|title...]")
+		}
+
+		sshKeyLogger.Timing("One moment, please...")
+		keys, err := utils.GetCurrentUserPublicKeys()
+		if err != nil {
+			return handleSSHKeyError(err)
+		}
+		toRemove := []octokit.Key{}
+		for _, arg := range c.Args() {
+			found := false
+			for _, k := range keys {
+				if strconv.Itoa(k.Id) == arg || k.Title == arg {
+					toRemove = append(toRemove, k)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("could not find an SSH key identified by '%s'", arg)
+			}
+		}
+
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Title", "Fingerprint"})
+		table.SetAutoFormatHeaders(true)
+		for _, k := range toRemove {
+			table.Append([]string{strconv.Itoa(k.Id), k.Title, keyFingerprint(&k)})
+		}
+		table.Render()
+		fmt.Println("")
+		if !utils.Confirm(fmt.Sprintf("Remove %d SSH key(s)? Machines using them will lose access. y/[n]", len(toRemove)), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		for _, k := range toRemove {
+			sshKeyLogger.Timing("Removing %s (%d)", k.Title, k.Id)
+			resp := utils.APIRequest("DELETE", &octokit.CurrentPublicKeyUrl, octokit.M{"id": k.Id}, nil, nil)
+			utils.HandleClientError(resp, sshKeyLogger)
+		}
+		sshKeyLogger.Success("Removed %d SSH key(s)", len(toRemove))
+		return nil
+	},
+}
+
+var sshKeyCheck = cli.Command{
+	Name:  "check",
+	Usage: "Compares local public keys with those registered on GitHub",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 0 {
+			return fmt.Errorf("usage: gh ssh-key check")
+		}
+		paths, err := utils.LocalSSHPublicKeys()
+		if err != nil {
+			return err
+		}
+
+		sshKeyLogger.Timing("One moment, please...")
+		keys, err := utils.GetCurrentUserPublicKeys()
+		if err != nil {
+			return handleSSHKeyError(err)
+		}
+		registered := map[int]*utils.SSHPublicKey{}
+		for _, k := range keys {
+			if key, err := utils.ParseSSHPublicKey(k.Key); err == nil {
+				registered[k.Id] = key
+			}
+		}
+
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Key", "Fingerprint", "Status"})
+		table.SetAutoFormatHeaders(true)
+		matched := map[int]bool{}
+		for _, path := range paths {
+			key, err := utils.ReadSSHPublicKey(path)
+			if err != nil {
+				table.Append([]string{path, "", "invalid: " + strings.TrimPrefix(err.Error(), path+": ")})
+				continue
+			}
+			status := "not registered"
+			for _, k := range keys {
+				if r, ok := registered[k.Id]; ok && r.Equal(key) {
+					status = fmt.Sprintf("registered as %s (%d)", k.Title, k.Id)
+					matched[k.Id] = true
+					break
+				}
+			}
+			table.Append([]string{path, key.Fingerprint(), status})
+		}
+		for _, k := range keys {
+			if !matched[k.Id] {
+				table.Append([]string{k.Title, keyFingerprint(&k), fmt.Sprintf("only on GitHub (%d)", k.Id)})
+			}
+		}
+		table.Render()
+		return nil
+	},
+}
+
+// SSHKey exposes commands managing SSH keys of the authenticated user
+var SSHKey = cli.Command{
+	Name:    "ssh-key",
+	Aliases: []string{"ssh-keys"},
+	Usage:   "Manages SSH keys of the authenticated user",
+	Subcommands: []cli.Command{
+		sshKeyList,
+		sshKeyAdd,
+		sshKeyRm,
+		sshKeyCheck,
+	},
+}
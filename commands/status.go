@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var statusLogger = utils.Logger.WithExtra("status")
+
+var statusRepoFlag = cli.StringFlag{
+	Name:  "repo",
+	Usage: "repository the commit belongs to. Defaults to the origin remote of the current directory",
+}
+
+// statusRepo returns the repository given through --repo, or the one the
+// origin remote of the current directory points to
+func statusRepo(c *cli.Context) (*utils.RepoURL, error) {
+	if repo := c.String("repo"); repo != "" {
+		repoURL := utils.RepoURLFromString(repo)
+		repoURL.AutoComplete()
+		return &repoURL, nil
+	}
+	repoURL, err := utils.RepoURLFromRemote("origin")
+	if err != nil {
+		return nil, fmt.Errorf("could not determine the repository from the origin remote. Please provide one using --repo")
+	}
+	return repoURL, nil
+}
+
+// statusRef returns the ref given as argument, or the SHA of the commit
+// checked out on the current directory
+func statusRef(args cli.Args) (string, error) {
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	sha, err := utils.Git("rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("could not determine the current commit. Please provide one")
+	}
+	return sha, nil
+}
+
+var statusSet = cli.Command{
+	Name:      "set",
+	Usage:     "Reports the status of a commit for a given context",
+	ArgsUsage: "(--state pending|success|failure|error) (--context NAME) (--url URL) (--description TEXT) (--repo REPOSITORY) ([sha|ref])",
+	Flags: []cli.Flag{
+		statusRepoFlag,
+		cli.StringFlag{
+			Name:  "state",
+			Usage: "either 'pending', 'success', 'failure' or 'error'",
+		},
+		cli.StringFlag{
+			Name:  "context",
+			Usage: "name identifying the system reporting the status, such as ci/build",
+			Value: "default",
+		},
+		cli.StringFlag{
+			Name:  "url",
+			Usage: "URL with details about the status, such as build logs",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "a short description of the status",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) > 1 {
+			return fmt.Errorf("usage: gh status set (--state pending|success|failure|error) (--context NAME) (--url URL) (--description TEXT) (--repo REPOSITORY) ([sha|ref])")
+		}
+		state := strings.ToLower(c.String("state"))
+		if state != "pending" && state != "success" && state != "failure" && state != "error" {
+			return fmt.Errorf("please specify a state: either 'pending', 'success', 'failure' or 'error'")
+		}
+		if len(c.String("description")) > 140 {
+			return fmt.Errorf("descriptions are limited to 140 characters")
+		}
+		repoURL, err := statusRepo(c)
+		if err != nil {
+			return err
+		}
+		ref, err := statusRef(c.Args())
+		if err != nil {
+			return err
+		}
+
+		// Statuses can only be set on SHAs
+		sha, resp := utils.ResolveRef(repoURL, ref)
+		utils.HandleClientError(resp, statusLogger)
+		url, err := octokit.StatusesURL.Expand(octokit.M{"owner": repoURL.Username, "repo": repoURL.RepoName, "ref": sha})
+		if err != nil {
+			return err
+		}
+		params := octokit.M{
+			"state":       state,
+			"context":     c.String("context"),
+			"target_url":  c.String("url"),
+			"description": c.String("description"),
+		}
+		_, resp = utils.NewClient().Statuses(url).Create(params)
+		utils.HandleClientError(resp, statusLogger)
+		statusLogger.Success("Marked %s as %s on %s", sha[:7], state, c.String("context"))
+		return nil
+	},
+}
+
+var statusShow = cli.Command{
+	Name:      "show",
+	Usage:     "Shows the combined status of all contexts of a commit",
+	ArgsUsage: "(--repo REPOSITORY) ([sha|ref])",
+	Flags:     []cli.Flag{statusRepoFlag},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) > 1 {
+			return fmt.Errorf("usage: gh status show (--repo REPOSITORY) ([sha|ref])")
+		}
+		repoURL, err := statusRepo(c)
+		if err != nil {
+			return err
+		}
+		ref, err := statusRef(c.Args())
+		if err != nil {
+			return err
+		}
+
+		statusLogger.Timing("One moment, please...")
+		status, resp := utils.GetCombinedStatus(repoURL, ref)
+		utils.HandleClientError(resp, statusLogger)
+
+		fmt.Println("")
+		if strings.HasPrefix(status.Sha, ref) {
+			color.New(color.Bold, color.Underline).Println(status.Sha[:7])
+		} else {
+			color.New(color.Bold, color.Underline).Printf("%s (%s)\n", ref, status.Sha[:7])
+		}
+		if status.TotalCount == 0 {
+			fmt.Println("No statuses reported")
+			return nil
+		}
+		fmt.Printf("Overall state: %s\n", status.State)
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Context", "State", "Description", "URL", "Updated"})
+		table.SetAutoFormatHeaders(true)
+		for _, s := range status.Statuses {
+			table.Append([]string{s.Context, s.State, s.Description, s.TargetURL, s.UpdatedAt.Format("2006-01-02 15:04")})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+// Status exposes commit status-related commands
+var Status = cli.Command{
+	Name:    "status",
+	Aliases: []string{"statuses"},
+	Usage:   "Reports and shows commit statuses",
+	Subcommands: []cli.Command{
+		statusSet,
+		statusShow,
+	},
+}
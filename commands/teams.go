@@ -13,6 +13,14 @@ import (
 
 var teamsLogger = utils.Logger.WithExtra("teams")
 
+func handleTeamsError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		teamsLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
 var teamsList = cli.Command{
 	Name:      "list",
 	Usage:     "Lists teams for a given organization",
@@ -44,22 +52,121 @@ var teamsList = cli.Command{
 var teamsMembers = cli.Command{
 	Name:      "members",
 	Usage:     "Lists members for a given organization team",
-	ArgsUsage: "[org] [team-slug]",
+	ArgsUsage: "(--role member|maintainer) (--no-names) [org] [team-slug]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "role",
+			Usage: "only lists members holding a given role, either 'member' or 'maintainer'",
+		},
+		cli.BoolFlag{
+			Name:  "no-names",
+			Usage: "does not list names of members, which requires one request per member",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) != 2 {
-			return fmt.Errorf("usage: gh teams members [org] [team-slug]")
+			return fmt.Errorf("usage: gh teams members (--role member|maintainer) (--no-names) [org] [team-slug]")
 		}
+		roleFilter := strings.ToLower(c.String("role"))
+		if roleFilter != "" && roleFilter != "member" && roleFilter != "maintainer" {
+			return fmt.Errorf("when filtering by role, please specify either 'maintainer' or 'member'")
+		}
+		orgName := c.Args()[0]
 		teamsLogger.Timing("One moment, please...")
-		members, _, err := utils.GetTeamMembers(c.Args()[0], c.Args()[1], teamsLogger)
+		team, err := utils.GetTeamByName(orgName, c.Args()[1], teamsLogger, true)
+		if err != nil {
+			return err
+		}
+		members, err := utils.GetTeamMembersByRole(team.ID, "all")
+		if err != nil {
+			return err
+		}
+		maintainers, err := utils.GetTeamMembersByRole(team.ID, "maintainer")
 		if err != nil {
 			return err
 		}
+		isMaintainer := map[string]bool{}
+		for _, m := range maintainers {
+			isMaintainer[m.Login] = true
+		}
+
+		invitations, err := utils.GetTeamInvitations(team.ID)
+		if err != nil {
+			teamsLogger.Warn("Could not fetch pending invitations for %s/%s", orgName, team.Slug)
+		}
+
+		// Only organization owners are able to check two-factor authentication
+		// status of members. Omit it for everyone else.
+		show2FA := true
+		without2FA := map[string]bool{}
+		if users, err := utils.GetFilteredUsersForOrg(orgName, "", "2fa_disabled"); err == nil {
+			for _, u := range users {
+				without2FA[u.Login] = true
+			}
+		} else {
+			show2FA = false
+		}
 
+		showNames := !c.Bool("no-names")
+		client := utils.NewClient()
+		header := []string{"User"}
+		if showNames {
+			header = append(header, "Name")
+		}
+		header = append(header, "Role", "State")
+		if show2FA {
+			header = append(header, "2FA?")
+		}
 		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Users"})
+		table.SetHeader(header)
 		table.SetAutoFormatHeaders(true)
 		for _, member := range members {
-			table.Append([]string{fmt.Sprintf("@%s", member.Login)})
+			role := "member"
+			if isMaintainer[member.Login] {
+				role = "maintainer"
+			}
+			if roleFilter != "" && role != roleFilter {
+				continue
+			}
+			row := []string{fmt.Sprintf("@%s", member.Login)}
+			if showNames {
+				url, err := octokit.UserURL.Expand(octokit.M{"user": member.Login})
+				if err != nil {
+					return err
+				}
+				user, resp := client.Users(url).One()
+				utils.HandleClientError(resp, teamsLogger)
+				row = append(row, user.Name)
+			}
+			row = append(row, role, "active")
+			if show2FA {
+				twoFactor := "Yes"
+				if without2FA[member.Login] {
+					twoFactor = "No"
+				}
+				row = append(row, twoFactor)
+			}
+			table.Append(row)
+		}
+		for _, inv := range invitations {
+			// Invitations don't carry team roles; invitees join as members.
+			role := "member"
+			if roleFilter != "" && role != roleFilter {
+				continue
+			}
+			handle := inv.Email
+			if inv.Login != "" {
+				handle = "@" + inv.Login
+			}
+			row := []string{handle}
+			if showNames {
+				row = append(row, "")
+			}
+			row = append(row, role, "pending")
+			if show2FA {
+				row = append(row, "")
+			}
+			table.Append(row)
 		}
 		table.Render()
 		return nil
@@ -92,14 +199,9 @@ var teamAddUser = cli.Command{
 			return nil
 		}
 
-		url, err := octokit.UserURL.Expand(octokit.M{"user": username})
-		if err != nil {
-			return err
-		}
-
+		user := utils.FindUser(orgName, username, teamsLogger)
+		username = user.Login
 		client := utils.NewClient()
-		user, resp := client.Users(url).One()
-		utils.HandleClientError(resp, teamsLogger)
 
 		userPresent := false
 		lowerCaseUser := strings.ToLower(username)
@@ -114,10 +216,7 @@ var teamAddUser = cli.Command{
 		fmt.Println("Hey there! You're about to add a new user to a team.")
 		fmt.Printf("Mind checking out whether this is the @%s you're looking for?\n", username)
 		fmt.Println("")
-		fmt.Printf("        Name: %s\n", user.Name)
-		fmt.Printf("       Email: %s\n", user.Email)
-		fmt.Printf("Organization: %s\n", user.Company)
-		fmt.Printf("         URL: %s\n", user.Blog)
+		printUserProfile(user)
 		fmt.Println("")
 		if !userPresent {
 			fmt.Printf("⚠️  WARNING! Continuing will invite @%s to the %s organization!\n\n", username, orgName)
@@ -127,7 +226,7 @@ var teamAddUser = cli.Command{
 		}
 
 		teamsLogger.Timing("One moment, please...")
-		_, resp = client.Teams().AddMembership(&octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": username}, role)
+		_, resp := client.Teams().AddMembership(&octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": username}, role)
 		utils.HandleClientError(resp, teamsLogger)
 		teamsLogger.Success("Added @%s to %s/%s", username, orgName, team.Slug)
 		return nil
@@ -151,28 +250,13 @@ var teamRmUser = cli.Command{
 			return nil
 		}
 
-		url, err := octokit.UserURL.Expand(octokit.M{"user": username})
-		if err != nil {
-			return err
-		}
-
-		client := utils.NewClient()
-		_, resp := client.Users(url).One()
-		utils.HandleClientError(resp, teamsLogger)
-
-		userPresent := false
-		lowerCaseUser := strings.ToLower(username)
-		for _, user := range members {
-			if strings.ToLower(user.Login) == lowerCaseUser {
-				userPresent = true
-				break
-			}
-		}
-
-		if !userPresent {
-			teamsLogger.Warn("@%s does not belong to %s/%s", orgName, team.Slug)
+		login := utils.ResolveLogin(username, members)
+		if login == "" {
+			teamsLogger.Warn("@%s does not belong to %s/%s", username, orgName, team.Slug)
 			os.Exit(1)
 		}
+		username = login
+		client := utils.NewClient()
 
 		fmt.Println("")
 		fmt.Println("Hey there! You're about to remove an user from a team.")
@@ -181,13 +265,59 @@ var teamRmUser = cli.Command{
 		}
 
 		teamsLogger.Timing("One moment, please...")
-		_, resp = client.Teams().RemoveMembership(&octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": username})
+		_, resp := client.Teams().RemoveMembership(&octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": username})
 		utils.HandleClientError(resp, teamsLogger)
 		teamsLogger.Success("Removed @%s from %s/%s", username, orgName, team.Slug)
 		return nil
 	},
 }
 
+var teamSetRole = cli.Command{
+	Name:      "set-role",
+	Usage:     "Promotes or demotes a team member",
+	ArgsUsage: "[username] [member|maintainer] [org] [team-slug]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 4 {
+			return fmt.Errorf("usage: gh teams set-role [username] [member|maintainer] [org] [team-slug]")
+		}
+		username := strings.TrimPrefix(c.Args()[0], "@")
+		role := strings.ToLower(c.Args()[1])
+		if role != "member" && role != "maintainer" {
+			return fmt.Errorf("when defining a role, please specify either 'maintainer' or 'member'")
+		}
+		orgName := c.Args()[2]
+		teamsLogger.Timing("One moment, please...")
+		team, err := utils.GetTeamByName(orgName, c.Args()[3], teamsLogger, true)
+		if err != nil {
+			return err
+		}
+
+		client := utils.NewClient()
+		membership, resp := client.Teams().GetMembership(&octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": username})
+		if utils.IsNotFound(resp) {
+			teamsLogger.Warn("@%s does not belong to %s/%s", username, orgName, team.Slug)
+			members, err := utils.GetTeamMembersByRole(team.ID, "all")
+			if err != nil {
+				return err
+			}
+			if username = utils.ResolveLogin(username, members); username == "" {
+				os.Exit(1)
+			}
+			membership, resp = client.Teams().GetMembership(&octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": username})
+		}
+		utils.HandleClientError(resp, teamsLogger)
+		if membership.Role == role {
+			teamsLogger.Info("@%s already is a %s of %s/%s", username, role, orgName, team.Slug)
+			return nil
+		}
+
+		_, resp = client.Teams().AddMembership(&octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": username}, role)
+		utils.HandleClientError(resp, teamsLogger)
+		teamsLogger.Success("@%s is now a %s of %s/%s", username, role, orgName, team.Slug)
+		return nil
+	},
+}
+
 // Teams exposes team-related commands
 var Teams = cli.Command{
 	Name:    "teams",
@@ -198,5 +328,14 @@ var Teams = cli.Command{
 		teamsMembers,
 		teamAddUser,
 		teamRmUser,
+		teamSetRole,
+		teamsSync,
+		teamsCreate,
+		teamsEdit,
+		teamsDelete,
+		teamsTree,
+		teamsRepos,
+		teamsGrant,
+		teamsRevoke,
 	},
 }
@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+func validatePrivacy(privacy string) error {
+	if privacy != "" && privacy != "secret" && privacy != "closed" {
+		return fmt.Errorf("when defining privacy, please specify either 'secret' or 'closed'")
+	}
+	return nil
+}
+
+var teamsCreate = cli.Command{
+	Name:      "create",
+	Usage:     "Creates a new team on an organization",
+	ArgsUsage: "(--description DESCRIPTION) (--privacy secret|closed) (--parent PARENT-SLUG) (--maintainer USERNAME...) [org] [name]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "a short description of the team",
+		},
+		cli.StringFlag{
+			Name:  "privacy",
+			Usage: "either 'secret', visible only to owners and members, or 'closed', visible to all organization members",
+		},
+		cli.StringFlag{
+			Name:  "parent",
+			Usage: "slug of a team to nest the new team under",
+		},
+		cli.StringSliceFlag{
+			Name:  "maintainer",
+			Usage: "a user to be added as a maintainer of the team. May be repeated",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh teams create (--description DESCRIPTION) (--privacy secret|closed) (--parent PARENT-SLUG) (--maintainer USERNAME...) [org] [name]")
+		}
+		privacy := strings.ToLower(c.String("privacy"))
+		if err := validatePrivacy(privacy); err != nil {
+			return err
+		}
+		orgName := c.Args()[0]
+		params := utils.TeamParams{
+			Name:        c.Args()[1],
+			Description: c.String("description"),
+			Privacy:     privacy,
+			Maintainers: c.StringSlice("maintainer"),
+		}
+
+		teamsLogger.Timing("One moment, please...")
+		if parent := c.String("parent"); parent != "" {
+			t, err := utils.GetTeamByName(orgName, parent, teamsLogger, true)
+			if err != nil {
+				return err
+			}
+			params.ParentTeamID = t.ID
+			if params.Privacy == "secret" {
+				return fmt.Errorf("nested teams cannot be secret")
+			}
+		}
+
+		var team octokit.Team
+		resp := utils.APIRequest("POST", &octokit.OrganizationTeamsURL, octokit.M{"org": orgName}, params, &team)
+		utils.HandleClientError(resp, teamsLogger)
+		teamsLogger.Success("Created %s/%s", orgName, team.Slug)
+		return nil
+	},
+}
+
+var teamsEdit = cli.Command{
+	Name:      "edit",
+	Usage:     "Updates a team's name, description, privacy or parent",
+	ArgsUsage: "(--name NAME) (--description DESCRIPTION) (--privacy secret|closed) (--parent PARENT-SLUG|--no-parent) [org] [team-slug]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "a new name for the team",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "a new description for the team",
+		},
+		cli.StringFlag{
+			Name:  "privacy",
+			Usage: "either 'secret' or 'closed'",
+		},
+		cli.StringFlag{
+			Name:  "parent",
+			Usage: "slug of a team to nest this team under",
+		},
+		cli.BoolFlag{
+			Name:  "no-parent",
+			Usage: "moves the team to the top level of the organization",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh teams edit (--name NAME) (--description DESCRIPTION) (--privacy secret|closed) (--parent PARENT-SLUG|--no-parent) [org] [team-slug]")
+		}
+		if c.IsSet("parent") && c.Bool("no-parent") {
+			return fmt.Errorf("--parent and --no-parent are mutually exclusive")
+		}
+		privacy := strings.ToLower(c.String("privacy"))
+		if err := validatePrivacy(privacy); err != nil {
+			return err
+		}
+		orgName := c.Args()[0]
+
+		teamsLogger.Timing("One moment, please...")
+		team, err := utils.GetTeamByName(orgName, c.Args()[1], teamsLogger, true)
+		if err != nil {
+			return err
+		}
+
+		// The API requires a name even when it is not being changed.
+		params := octokit.M{"name": team.Name}
+		if c.IsSet("name") {
+			params["name"] = c.String("name")
+		}
+		if c.IsSet("description") {
+			params["description"] = c.String("description")
+		}
+		if privacy != "" {
+			params["privacy"] = privacy
+		}
+		if c.Bool("no-parent") {
+			params["parent_team_id"] = nil
+		} else if parent := c.String("parent"); parent != "" {
+			p, err := utils.GetTeamByName(orgName, parent, teamsLogger, true)
+			if err != nil {
+				return err
+			}
+			if p.ID == team.ID {
+				return fmt.Errorf("a team cannot be its own parent")
+			}
+			params["parent_team_id"] = p.ID
+		}
+		if len(params) == 1 && !c.IsSet("name") {
+			return fmt.Errorf("nothing to change. Please provide at least one option")
+		}
+
+		var updated octokit.Team
+		resp := utils.APIRequest("PATCH", &octokit.TeamURL, octokit.M{"id": team.ID}, params, &updated)
+		utils.HandleClientError(resp, teamsLogger)
+		teamsLogger.Success("Updated %s/%s", orgName, updated.Slug)
+		return nil
+	},
+}
+
+var teamsDelete = cli.Command{
+	Name:      "delete",
+	Usage:     "Deletes a team",
+	ArgsUsage: "[org] [team-slug]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh teams delete [org] [team-slug]")
+		}
+		orgName := c.Args()[0]
+
+		teamsLogger.Timing("One moment, please...")
+		team, err := utils.GetTeamByName(orgName, c.Args()[1], teamsLogger, true)
+		if err != nil {
+			return err
+		}
+		teams, err := utils.GetAllNestedTeamsForOrg(orgName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Hey! You're about to perform a really dangerous action.")
+		if children := utils.ChildTeams(teams, team.ID); len(children) > 0 {
+			slugs := []string{}
+			for _, child := range children {
+				slugs = append(slugs, "@"+child.Slug)
+			}
+			fmt.Printf("⚠️  WARNING! The following child teams will also be deleted: %s\n", strings.Join(slugs, ", "))
+		}
+		fmt.Printf("To confirm you really want to delete %s/%s, please enter its name again:\n", orgName, team.Slug)
+		if !utils.ConfirmName("What is its name again? ", team.Slug) {
+			fmt.Println("Nope. That's not its name. Aborting.")
+			return fmt.Errorf("aborted")
+		}
+
+		teamsLogger.Info("Removing %s/%s...", orgName, team.Slug)
+		resp := utils.APIRequest("DELETE", &octokit.TeamURL, octokit.M{"id": team.ID}, nil, nil)
+		utils.HandleClientError(resp, teamsLogger)
+		teamsLogger.Success("Removed %s/%s", orgName, team.Slug)
+		return nil
+	},
+}
+
+var teamsTree = cli.Command{
+	Name:      "tree",
+	Usage:     "Shows the hierarchy of teams of an organization",
+	ArgsUsage: "[org]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh teams tree [org]")
+		}
+		orgName := c.Args()[0]
+		teamsLogger.Timing("One moment, please...")
+		teams, err := utils.GetAllNestedTeamsForOrg(orgName)
+		if err != nil {
+			if err, ok := err.(*octokit.ResponseError); ok {
+				teamsLogger.Error("%s", utils.FormatError(err))
+				os.Exit(1)
+			}
+			return err
+		}
+
+		children := map[int][]utils.NestedTeam{}
+		for _, t := range teams {
+			parentID := 0
+			if t.Parent != nil {
+				parentID = t.Parent.ID
+			}
+			children[parentID] = append(children[parentID], t)
+		}
+		for _, list := range children {
+			sort.Slice(list, func(i, j int) bool {
+				return strings.ToLower(list[i].Slug) < strings.ToLower(list[j].Slug)
+			})
+		}
+
+		var printTree func(parentID int, prefix string)
+		printTree = func(parentID int, prefix string) {
+			list := children[parentID]
+			for i, t := range list {
+				branch, next := "├── ", "│   "
+				if i == len(list)-1 {
+					branch, next = "└── ", "    "
+				}
+				fmt.Printf("%s%s%s (@%s)\n", prefix, branch, t.Name, t.Slug)
+				printTree(t.ID, prefix+next)
+			}
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println(orgName)
+		if len(teams) == 0 {
+			fmt.Println("No teams")
+			return nil
+		}
+		printTree(0, "")
+		return nil
+	},
+}
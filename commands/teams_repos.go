@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+// teamRepoArgs parses a list of repositories in the form repo(:permission)
+// along with an optional trailing :permission applied to all of them.
+// Repositories are required to belong to the given organization.
+func teamRepoArgs(org string, args []string, withPermission bool) ([]utils.RepoURL, map[string]string, error) {
+	defaultPermission := ""
+	if withPermission && len(args) > 0 && strings.HasPrefix(args[len(args)-1], ":") {
+		defaultPermission = strings.TrimPrefix(args[len(args)-1], ":")
+		args = args[:len(args)-1]
+	}
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("at least one repository is required")
+	}
+
+	repos := []utils.RepoURL{}
+	permissions := map[string]string{}
+	for _, arg := range args {
+		permission := defaultPermission
+		if strings.Contains(arg, ":") {
+			if !withPermission {
+				return nil, nil, fmt.Errorf("permission levels are not accepted by this command")
+			}
+			split := strings.SplitN(arg, ":", 2)
+			arg, permission = split[0], split[1]
+		}
+		r := utils.RepoURLFromString(arg)
+		if r.Username == "" {
+			r.Username = org
+		}
+		if !strings.EqualFold(r.Username, org) {
+			return nil, nil, fmt.Errorf("%s does not belong to the %s organization", r.ToURL(), org)
+		}
+		if permission != "" {
+			p, err := utils.ParsePermission(permission, org)
+			if err != nil {
+				return nil, nil, err
+			}
+			permission = p
+		}
+		repos = append(repos, r)
+		permissions[r.ToURL()] = permission
+	}
+	return repos, permissions, nil
+}
+
+var teamsRepos = cli.Command{
+	Name:      "repos",
+	Usage:     "Lists repositories a team has access to",
+	ArgsUsage: "[org] [team-slug]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh teams repos [org] [team-slug]")
+		}
+		orgName := c.Args()[0]
+		teamsLogger.Timing("One moment, please...")
+		team, err := utils.GetTeamByName(orgName, c.Args()[1], teamsLogger, true)
+		if err != nil {
+			return err
+		}
+		repos, err := utils.GetAllReposForTeam(team.ID)
+		if err != nil {
+			if err, ok := err.(*octokit.ResponseError); ok {
+				teamsLogger.Error("%s", utils.FormatError(err))
+				os.Exit(1)
+			}
+			return err
+		}
+		sort.Slice(repos, func(i, j int) bool {
+			return strings.ToLower(repos[i].FullName) < strings.ToLower(repos[j].FullName)
+		})
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Printf("%s/%s\n", orgName, team.Slug)
+		if len(repos) == 0 {
+			fmt.Println("No repositories")
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"🔒", "Repository", "Permission"})
+		table.SetAutoFormatHeaders(true)
+		for _, repo := range repos {
+			access := "  "
+			if repo.Private {
+				access = "🔒"
+			}
+			table.Append([]string{access, repo.FullName, repo.Role()})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var teamsGrant = cli.Command{
+	Name:      "grant",
+	Usage:     "Grants a team access to one or more repositories",
+	ArgsUsage: "[org] [team-slug] [repository(:permission)...] (:permission)",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 3 {
+			return fmt.Errorf("usage: gh teams grant [org] [team-slug] [repository(:permission)...] (:permission)")
+		}
+		orgName := c.Args()[0]
+		teamsLogger.Timing("One moment, please...")
+		team, err := utils.GetTeamByName(orgName, c.Args()[1], teamsLogger, true)
+		if err != nil {
+			return err
+		}
+		repos, permissions, err := teamRepoArgs(orgName, c.Args()[2:], true)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("")
+		fmt.Printf("Hey there! You're about to grant %s/%s access to:\n", orgName, team.Slug)
+		for _, r := range repos {
+			permission := permissions[r.ToURL()]
+			if permission == "" {
+				permission = "team default"
+			}
+			fmt.Printf("    - %s (%s)\n", r.ToURL(), permission)
+		}
+		fmt.Println("")
+		if !utils.Confirm("Continue? y/[n]", false) {
+			return fmt.Errorf("aborting")
+		}
+
+		failed := []string{}
+		for _, r := range repos {
+			teamsLogger.Timing("Granting %s/%s access to %s", orgName, team.Slug, r.ToURL())
+			if resp := utils.SetTeamRepoPermission(team.ID, &r, permissions[r.ToURL()]); resp.HasError() {
+				teamsLogger.Warn("Could not grant access to %s: %s", r.ToURL(), utils.ResultError(resp))
+				failed = append(failed, r.ToURL())
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("could not grant %s/%s access to %s", orgName, team.Slug, strings.Join(failed, ", "))
+		}
+		teamsLogger.Success("Granted %s/%s access to %d repositories", orgName, team.Slug, len(repos))
+		return nil
+	},
+}
+
+var teamsRevoke = cli.Command{
+	Name:      "revoke",
+	Usage:     "Revokes a team's access to one or more repositories",
+	ArgsUsage: "[org] [team-slug] [repository...]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 3 {
+			return fmt.Errorf("usage: gh teams revoke [org] [team-slug] [repository...]")
+		}
+		orgName := c.Args()[0]
+		teamsLogger.Timing("One moment, please...")
+		team, err := utils.GetTeamByName(orgName, c.Args()[1], teamsLogger, true)
+		if err != nil {
+			return err
+		}
+		repos, _, err := teamRepoArgs(orgName, c.Args()[2:], false)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("")
+		fmt.Printf("Hey there! You're about to revoke access of %s/%s to:\n", orgName, team.Slug)
+		for _, r := range repos {
+			fmt.Printf("    - %s\n", r.ToURL())
+		}
+		fmt.Println("")
+		if !utils.Confirm("Continue? y/[n]", false) {
+			return fmt.Errorf("aborting")
+		}
+
+		failed := []string{}
+		for _, r := range repos {
+			teamsLogger.Timing("Revoking access of %s/%s to %s", orgName, team.Slug, r.ToURL())
+			if resp := utils.RemoveTeamRepo(team.ID, &r); resp.HasError() {
+				teamsLogger.Warn("Could not revoke access to %s: %s", r.ToURL(), utils.ResultError(resp))
+				failed = append(failed, r.ToURL())
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("could not revoke access of %s/%s to %s", orgName, team.Slug, strings.Join(failed, ", "))
+		}
+		teamsLogger.Success("Revoked access of %s/%s to %d repositories", orgName, team.Slug, len(repos))
+		return nil
+	},
+}
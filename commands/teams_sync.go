@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+// readLogins reads one username per line, ignoring blank lines and comments
+// starting with #
+func readLogins(r io.Reader) ([]string, error) {
+	logins := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx > -1 {
+			line = line[:idx]
+		}
+		line = strings.TrimPrefix(strings.TrimSpace(line), "@")
+		if line != "" {
+			logins = append(logins, line)
+		}
+	}
+	return logins, scanner.Err()
+}
+
+var teamsSync = cli.Command{
+	Name:      "sync",
+	Usage:     "Synchronizes team members with a list of users or another team",
+	ArgsUsage: "(--from FILE|--from-team TEAM-SLUG) (--dry-run) (--no-remove) [org] [team-slug]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "a file containing one username per line",
+		},
+		cli.StringFlag{
+			Name:  "from-team",
+			Usage: "slug of a team on the same organization to copy members from",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only shows changes, without applying them",
+		},
+		cli.BoolFlag{
+			Name:  "no-remove",
+			Usage: "does not remove members absent from the source",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 2 {
+			return fmt.Errorf("usage: gh teams sync (--from FILE|--from-team TEAM-SLUG) (--dry-run) (--no-remove) [org] [team-slug]")
+		}
+		if (c.String("from") == "") == (c.String("from-team") == "") {
+			return fmt.Errorf("please provide either --from or --from-team")
+		}
+		orgName := c.Args()[0]
+
+		var wanted []string
+		if from := c.String("from"); from != "" {
+			f, err := os.Open(from)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			logins, err := readLogins(f)
+			if err != nil {
+				return err
+			}
+			wanted = logins
+		}
+
+		teamsLogger.Timing("One moment, please...")
+		if fromTeam := c.String("from-team"); fromTeam != "" {
+			members, _, err := utils.GetTeamMembers(orgName, fromTeam, teamsLogger)
+			if err != nil {
+				return err
+			}
+			for _, m := range members {
+				wanted = append(wanted, m.Login)
+			}
+		}
+
+		members, team, err := utils.GetTeamMembers(orgName, c.Args()[1], teamsLogger)
+		if err != nil {
+			return err
+		}
+		maintainers, err := utils.GetTeamMembersByRole(team.ID, "maintainer")
+		if err != nil {
+			return handleTeamsError(err)
+		}
+		childTeams, err := utils.GetChildTeamMemberships(orgName, team.ID)
+		if err != nil {
+			return handleTeamsError(err)
+		}
+		invitations, err := utils.GetTeamInvitations(team.ID)
+		if err != nil {
+			return err
+		}
+		orgUsers, err := utils.GetAllUsersForOrg(orgName)
+		if err != nil {
+			return err
+		}
+
+		current := map[string]string{}
+		for _, m := range members {
+			current[strings.ToLower(m.Login)] = m.Login
+		}
+		isMaintainer := map[string]bool{}
+		for _, m := range maintainers {
+			isMaintainer[strings.ToLower(m.Login)] = true
+		}
+		// Users already invited through this team will join it once they
+		// accept their invitation.
+		for _, inv := range invitations {
+			if inv.Login != "" {
+				current[strings.ToLower(inv.Login)] = inv.Login
+			}
+		}
+		inOrg := map[string]bool{}
+		for _, u := range orgUsers {
+			inOrg[strings.ToLower(u.Login)] = true
+		}
+
+		toAdd := []string{}
+		inherited := []string{}
+		seen := map[string]bool{}
+		for _, login := range wanted {
+			key := strings.ToLower(login)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, present := current[key]; !present {
+				toAdd = append(toAdd, login)
+			} else if len(childTeams[key]) > 0 {
+				inherited = append(inherited, current[key])
+			}
+		}
+		toRemove := []string{}
+		if !c.Bool("no-remove") {
+			for key, login := range current {
+				if !seen[key] {
+					toRemove = append(toRemove, login)
+				}
+			}
+		}
+		sort.Sort(utils.Alphabetic(toAdd))
+		sort.Sort(utils.Alphabetic(toRemove))
+		sort.Sort(utils.Alphabetic(inherited))
+
+		// Check every login up front, so a typo does not interrupt the sync
+		// after some changes were applied
+		unknown := []string{}
+		for _, login := range toAdd {
+			if inOrg[strings.ToLower(login)] {
+				continue
+			}
+			if _, resp := utils.GetUser(login); resp.HasError() {
+				if !utils.IsNotFound(resp) {
+					utils.HandleClientError(resp, teamsLogger)
+				}
+				unknown = append(unknown, "@"+login)
+			}
+		}
+		if len(unknown) > 0 {
+			return fmt.Errorf("could not find user(s) %s", strings.Join(unknown, ", "))
+		}
+
+		if len(toAdd) == 0 && len(toRemove) == 0 {
+			teamsLogger.Success("%s/%s is already in sync", orgName, team.Slug)
+			return nil
+		}
+
+		fmt.Println("")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"", "User", "Notes"})
+		table.SetAutoFormatHeaders(true)
+		invites := 0
+		for _, login := range toAdd {
+			notes := ""
+			if !inOrg[strings.ToLower(login)] {
+				notes = fmt.Sprintf("will be invited to %s", orgName)
+				invites++
+			}
+			table.Append([]string{"+", "@" + login, notes})
+		}
+		for _, login := range toRemove {
+			notes := []string{}
+			if isMaintainer[strings.ToLower(login)] {
+				notes = append(notes, "maintainer")
+			}
+			if slugs := childTeams[strings.ToLower(login)]; len(slugs) > 0 {
+				notes = append(notes, fmt.Sprintf("kept while a member of %s", strings.Join(slugs, ", ")))
+			}
+			table.Append([]string{"-", "@" + login, strings.Join(notes, "; ")})
+		}
+		for _, login := range inherited {
+			table.Append([]string{"", "@" + login, fmt.Sprintf("member through %s", strings.Join(childTeams[strings.ToLower(login)], ", "))})
+		}
+		table.Render()
+		fmt.Println("")
+
+		if c.Bool("dry-run") {
+			teamsLogger.Info("Dry run: %d addition(s) and %d removal(s) were not applied", len(toAdd), len(toRemove))
+			return nil
+		}
+		if invites > 0 {
+			fmt.Printf("⚠️  WARNING! Continuing will invite %d user(s) to the %s organization!\n\n", invites, orgName)
+		}
+		if !utils.Confirm(fmt.Sprintf("Apply %d addition(s) and %d removal(s) to %s/%s? y/[n]", len(toAdd), len(toRemove), orgName, team.Slug), false) {
+			return fmt.Errorf("aborting")
+		}
+
+		failed := []string{}
+		for _, login := range toAdd {
+			teamsLogger.Timing("Adding @%s", login)
+			resp := utils.APIRequest("PUT", &octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": login}, octokit.M{"role": "member"}, nil)
+			if resp.HasError() {
+				teamsLogger.Warn("Could not add @%s: %s", login, teamsSyncError(resp))
+				failed = append(failed, "@"+login)
+			}
+		}
+		for _, login := range toRemove {
+			teamsLogger.Timing("Removing @%s", login)
+			resp := utils.APIRequest("DELETE", &octokit.TeamMembershipURL, octokit.M{"id": team.ID, "username": login}, nil, nil)
+			// Memberships inherited from child teams cannot be removed
+			// from their parents
+			if utils.IsNotFound(resp) && len(childTeams[strings.ToLower(login)]) > 0 {
+				teamsLogger.Info("Kept @%s, who is a member through %s", login, strings.Join(childTeams[strings.ToLower(login)], ", "))
+				continue
+			}
+			if resp.HasError() {
+				teamsLogger.Warn("Could not remove @%s: %s", login, teamsSyncError(resp))
+				failed = append(failed, "@"+login)
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("could not synchronize %s on %s/%s", strings.Join(failed, ", "), orgName, team.Slug)
+		}
+		teamsLogger.Success("Synchronized %s/%s", orgName, team.Slug)
+		return nil
+	},
+}
+
+// teamsSyncError describes why a membership change failed
+func teamsSyncError(resp *octokit.Result) string {
+	if err, ok := resp.Err.(*octokit.ResponseError); ok {
+		return strings.TrimSpace(utils.FormatError(err))
+	}
+	return resp.Err.Error()
+}
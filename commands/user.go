@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+	"github.com/victorgama/gh/utils"
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var userLogger = utils.Logger.WithExtra("user")
+
+// printUserProfile prints a short profile of a given user, used to make sure
+// the right person is being dealt with
+func printUserProfile(user *octokit.User) {
+	fmt.Printf("        Name: %s\n", user.Name)
+	fmt.Printf("       Email: %s\n", user.Email)
+	fmt.Printf("Organization: %s\n", user.Company)
+	fmt.Printf("         URL: %s\n", user.Blog)
+}
+
+func handleUserError(err error) error {
+	if err, ok := err.(*octokit.ResponseError); ok {
+		userLogger.Error("%s", utils.FormatError(err))
+		os.Exit(1)
+	}
+	return err
+}
+
+// User shows the public profile of a given user
+var User = cli.Command{
+	Name:      "user",
+	Usage:     "Shows a user's profile, organizations and public keys",
+	ArgsUsage: "[username]",
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) != 1 {
+			return fmt.Errorf("usage: gh user [username]")
+		}
+		username := strings.TrimPrefix(c.Args()[0], "@")
+
+		userLogger.Timing("One moment, please...")
+		user, resp := utils.GetUser(username)
+		utils.HandleClientError(resp, userLogger)
+		orgs, err := utils.GetUserOrganizations(user.Login)
+		if err != nil {
+			return handleUserError(err)
+		}
+		sshKeys, err := utils.GetUserPublicKeys(user.Login)
+		if err != nil {
+			return handleUserError(err)
+		}
+		gpgKeys, err := utils.GetUserGPGKeys(user.Login)
+		if err != nil {
+			return handleUserError(err)
+		}
+
+		orgNames := []string{}
+		for _, o := range orgs {
+			orgNames = append(orgNames, o.Login)
+		}
+		memberSince := ""
+		if user.CreatedAt != nil {
+			memberSince = user.CreatedAt.Format("2006-01-02")
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Printf("@%s\n", user.Login)
+		printUserProfile(user)
+		fmt.Printf("    Location: %s\n", user.Location)
+		fmt.Printf("         Bio: %s\n", user.Bio)
+		fmt.Printf("Member since: %s\n", memberSince)
+		fmt.Println("")
+		fmt.Printf("Public repos: %d\n", user.PublicRepos)
+		fmt.Printf("   Followers: %d\n", user.Followers)
+		fmt.Printf("   Following: %d\n", user.Following)
+		fmt.Printf("        Orgs: %s\n", strings.Join(orgNames, ", "))
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println("SSH keys")
+		if len(sshKeys) == 0 {
+			fmt.Println("No public SSH keys")
+		}
+		for _, k := range sshKeys {
+			fmt.Println(k.Key)
+		}
+
+		fmt.Println("")
+		color.New(color.Bold, color.Underline).Println("GPG keys")
+		if len(gpgKeys) == 0 {
+			fmt.Println("No public GPG keys")
+		}
+		for _, k := range gpgKeys {
+			emails := []string{}
+			for _, e := range k.Emails {
+				emails = append(emails, e.Email)
+			}
+			expires := "never expires"
+			if k.ExpiresAt != nil {
+				expires = "expires " + k.ExpiresAt.Format("2006-01-02")
+			}
+			fmt.Printf("%s (%s) %s\n", k.KeyID, expires, strings.Join(emails, ", "))
+		}
+		return nil
+	},
+}
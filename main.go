@@ -26,7 +26,22 @@ func main() {
 		commands.RepoList,
 		commands.Collab,
 		commands.Teams,
+		commands.Org,
+		commands.Offboard,
+		commands.User,
+		commands.Issue,
+		commands.PR,
+		commands.Release,
+		commands.Search,
+		commands.Pages,
+		commands.Status,
+		commands.Milestone,
+		commands.Label,
+		commands.Gist,
+		commands.SSHKey,
+		commands.DeployKey,
 		commands.Open,
+		commands.Invites,
 	}
 	app.Run(os.Args)
 }
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+const apiMediaType = "application/vnd.github.v3+json;charset=utf-8"
+
+// APIRequest performs a request against an endpoint not covered by octokit's
+// services, encoding input as the request body and decoding the response into
+// output. Both may be nil. The returned Result carries a NextPage link when
+// the endpoint is paginated.
+func APIRequest(method string, uri *octokit.Hyperlink, params octokit.M, input, output interface{}) *octokit.Result {
+	url, err := uri.Expand(params)
+	if err != nil {
+		return &octokit.Result{Err: err}
+	}
+	req, err := NewClient().NewRequest(url.String())
+	if err != nil {
+		return &octokit.Result{Err: err}
+	}
+	if input != nil {
+		body, err := json.Marshal(input)
+		if err != nil {
+			return &octokit.Result{Err: err}
+		}
+		req.Header.Set("Content-Type", apiMediaType)
+		req.ContentLength = int64(len(body))
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	sawyerResp := req.Request.Do(method)
+	resp, err := octokit.NewResponse(sawyerResp)
+	if err == nil && output != nil && sawyerResp.MediaType != nil {
+		err = sawyerResp.Decode(output)
+	}
+	// Bodies left undecoded must still be drained and closed, so their
+	// connections may be reused
+	if !sawyerResp.BodyClosed && sawyerResp.Response != nil && sawyerResp.Body != nil {
+		io.Copy(ioutil.Discard, sawyerResp.Body)
+		sawyerResp.Body.Close()
+		sawyerResp.BodyClosed = true
+	}
+
+	result := &octokit.Result{Response: resp, Err: err}
+	if resp != nil {
+		if link, ok := resp.MediaHeader.Relations["next"]; ok {
+			next := octokit.Hyperlink(link)
+			result.NextPage = &next
+		}
+	}
+	return result
+}
+
+// IsNotFound determines whether a given Result failed with a 404 status
+func IsNotFound(resp *octokit.Result) bool {
+	if err, ok := resp.Err.(*octokit.ResponseError); ok {
+		return err.Type == octokit.ErrorNotFound
+	}
+	return false
+}
+
+// IsUnprocessable determines whether a given Result failed with a 422 status
+func IsUnprocessable(resp *octokit.Result) bool {
+	if err, ok := resp.Err.(*octokit.ResponseError); ok {
+		return err.Type == octokit.ErrorUnprocessableEntity
+	}
+	return false
+}
@@ -41,6 +41,15 @@ func FormatError(err *octokit.ResponseError) string {
 	return str
 }
 
+// ResultError describes why a given failed octokit Result failed, in a
+// single line
+func ResultError(resp *octokit.Result) string {
+	if err, ok := resp.Err.(*octokit.ResponseError); ok {
+		return strings.Replace(strings.TrimSpace(FormatError(err)), "\n   ", ";", -1)
+	}
+	return resp.Err.Error()
+}
+
 // HandleClientError checks the state of an octokit Result, prints
 // and exits the application if an error ocurred during the request
 func HandleClientError(resp *octokit.Result, logger pine.Writer) {
@@ -82,23 +91,8 @@ func GetAllUserRepositories() ([]octokit.Repository, error) {
 }
 
 // GetAllCollabs returns a list of all collaborators of a given repository
-func GetAllCollabs(url *RepoURL) ([]octokit.User, error) {
-	client := NewClient()
-	result := []octokit.User{}
-
-	users, resp := client.Collaborators().All(&octokit.CollaboratorsURL, octokit.M{"owner": url.Username, "repo": url.RepoName})
-	for {
-		if resp.HasError() {
-			return nil, resp.Err
-		}
-		result = append(result, users...)
-		if resp.NextPage != nil {
-			users, resp = client.Collaborators().All(resp.NextPage, nil)
-		} else {
-			break
-		}
-	}
-	return result, nil
+func GetAllCollabs(url *RepoURL) ([]Collaborator, error) {
+	return GetCollabsByAffiliation(url, "all")
 }
 
 // GetAllTeamsForRepo returns a list of teams that have access to a given repository
@@ -141,7 +135,10 @@ func GetAllTeamsForOrg(org string) ([]octokit.Team, error) {
 	return result, nil
 }
 
-// GetTeamByName returns a Team instance belonging to a given organization under a given name
+// GetTeamByName returns a Team instance belonging to a given organization
+// under a given slug or name. When exitOnError is set and no team is found,
+// the closest teams are suggested before exiting; when running
+// interactively, one of them may be picked instead.
 func GetTeamByName(org, team string, logger pine.Writer, exitOnError bool) (*octokit.Team, error) {
 	teams, err := GetAllTeamsForOrg(org)
 	if err != nil {
@@ -159,22 +156,58 @@ func GetTeamByName(org, team string, logger pine.Writer, exitOnError bool) (*oct
 			break
 		}
 	}
+	if t == nil {
+		for _, rt := range teams {
+			if strings.ToLower(rt.Name) == teamName {
+				t = &rt
+				break
+			}
+		}
+	}
 	if t == nil && exitOnError {
 		logger.Warn("Could not find a team named '%s' on the organization '%s'", team, org)
+		names := []string{}
+		for _, rt := range teams {
+			names = append(names, rt.Slug, rt.Name)
+		}
+		if picked := Suggest(team, names); picked != "" {
+			return GetTeamByName(org, picked, logger, exitOnError)
+		}
 		os.Exit(1)
 	}
 	return t, nil
 }
 
+// ResolveLogin looks for a given login among a list of users, returning it as
+// spelled by GitHub. When absent, the closest logins are suggested and, when
+// running interactively, one of them may be picked. Returns an empty string
+// in case no user was found.
+func ResolveLogin(login string, users []octokit.User) string {
+	login = strings.TrimPrefix(login, "@")
+	logins := []string{}
+	for _, u := range users {
+		if strings.EqualFold(u.Login, login) {
+			return u.Login
+		}
+		logins = append(logins, u.Login)
+	}
+	return Suggest(login, logins)
+}
+
 // GetTeamMembers returns a list of members of a given organization team
 func GetTeamMembers(org, team string, logger pine.Writer) ([]octokit.User, *octokit.Team, error) {
 	t, err := GetTeamByName(org, team, logger, true)
 	if err != nil {
 		return nil, nil, err
 	}
-	client := NewClient()
-	members, resp := client.Teams().GetMembers(&octokit.TeamMembersURL, octokit.M{"id": t.ID})
-	HandleClientError(resp, logger)
+	members, err := GetTeamMembersByRole(t.ID, "all")
+	if err != nil {
+		if err, ok := err.(*octokit.ResponseError); ok {
+			logger.Error("%s", FormatError(err))
+			os.Exit(1)
+		}
+		return nil, nil, err
+	}
 	return members, t, nil
 }
 
@@ -197,3 +230,25 @@ func GetAllUsersForOrg(org string) ([]octokit.User, error) {
 	}
 	return result, nil
 }
+
+// GetCollabsByAffiliation returns a list of collaborators of a given repository
+// filtered by affiliation. Valid values are outside, direct and all
+func GetCollabsByAffiliation(url *RepoURL, affiliation string) ([]Collaborator, error) {
+	result := []Collaborator{}
+
+	users := []Collaborator{}
+	resp := APIRequest("GET", &AffiliatedCollaboratorsURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "affiliation": affiliation}, nil, &users)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, users...)
+		if resp.NextPage != nil {
+			users = []Collaborator{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &users)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// CompareURL is the template for the comparison between two branches,
+	// tags or SHAs
+	CompareURL = octokit.Hyperlink("repos/{owner}/{repo}/compare/{+base}...{+head}{?per_page}")
+)
+
+// Comparison represents how a head commit relates to a base one, along with
+// commits reachable from head but not from base, oldest first
+type Comparison struct {
+	Status       string           `json:"status,omitempty"`
+	AheadBy      int              `json:"ahead_by,omitempty"`
+	BehindBy     int              `json:"behind_by,omitempty"`
+	TotalCommits int              `json:"total_commits,omitempty"`
+	Commits      []octokit.Commit `json:"commits,omitempty"`
+}
+
+// ResolveRef returns the SHA of the commit a given branch, tag or SHA points
+// to
+func ResolveRef(url *RepoURL, ref string) (string, *octokit.Result) {
+	commit, resp := NewClient().Commits().One(&octokit.CommitsURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "sha": ref})
+	return commit.Sha, resp
+}
+
+// GetCommitsBetween returns commits reachable from to but not from from,
+// following only first parents, newest first. Commits made on branches later
+// merged are therefore represented by their merge commits.
+func GetCommitsBetween(url *RepoURL, from, to string) ([]octokit.Commit, error) {
+	walked := map[string]octokit.Commit{}
+	var comparison Comparison
+	resp := APIRequest("GET", &CompareURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "base": from, "head": to, "per_page": 100}, nil, &comparison)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		switch comparison.Status {
+		case "identical":
+			return []octokit.Commit{}, nil
+		case "behind", "diverged":
+			return nil, fmt.Errorf("%s is not an ancestor of %s", from, to)
+		}
+		for _, c := range comparison.Commits {
+			walked[c.Sha] = c
+		}
+		if resp.NextPage == nil {
+			break
+		}
+		comparison = Comparison{}
+		resp = APIRequest("GET", resp.NextPage, nil, nil, &comparison)
+	}
+
+	fromSha, resp := ResolveRef(url, from)
+	if resp.HasError() {
+		return nil, resp.Err
+	}
+	toSha, resp := ResolveRef(url, to)
+	if resp.HasError() {
+		return nil, resp.Err
+	}
+	result := []octokit.Commit{}
+	for sha := toSha; sha != fromSha; {
+		c, ok := walked[sha]
+		if !ok || len(c.Parents) == 0 {
+			return nil, fmt.Errorf("%s is not an ancestor of %s following first parents", from, to)
+		}
+		result = append(result, c)
+		sha = c.Parents[0].Sha
+	}
+	return result, nil
+}
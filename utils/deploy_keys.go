@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// DeployKeysURL is the template for deploy keys of a repository
+	DeployKeysURL = octokit.Hyperlink("repos/{owner}/{repo}/keys{/id}")
+)
+
+// DeployKey represents an SSH key granting access to a single repository
+type DeployKey struct {
+	ID        int        `json:"id,omitempty"`
+	Key       string     `json:"key,omitempty"`
+	Title     string     `json:"title,omitempty"`
+	ReadOnly  bool       `json:"read_only"`
+	Verified  bool       `json:"verified,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// GetDeployKeys returns all deploy keys of a given repository
+func GetDeployKeys(url *RepoURL) ([]DeployKey, error) {
+	result := []DeployKey{}
+
+	keys := []DeployKey{}
+	resp := APIRequest("GET", &DeployKeysURL, octokit.M{"owner": url.Username, "repo": url.RepoName}, nil, &keys)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, keys...)
+		if resp.NextPage != nil {
+			keys = []DeployKey{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &keys)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// AddDeployKey adds a public key to a given repository, granting it read-only
+// access unless readWrite is set
+func AddDeployKey(url *RepoURL, title string, key *SSHPublicKey, readWrite bool) (*DeployKey, *octokit.Result) {
+	var created DeployKey
+	params := octokit.M{"title": title, "key": key.String(), "read_only": !readWrite}
+	resp := APIRequest("POST", &DeployKeysURL, octokit.M{"owner": url.Username, "repo": url.RepoName}, params, &created)
+	return &created, resp
+}
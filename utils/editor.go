@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditText opens the user's editor, as defined by the VISUAL or EDITOR
+// environment variables, on a temporary file filled with a given text, and
+// returns its contents once the editor exits
+func EditText(initial string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := ioutil.TempFile("", "gh-edit-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	// Editors are commonly defined along with arguments, such as "code -w"
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	data, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
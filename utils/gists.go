@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+// GistID extracts a gist ID from either an ID or a gist URL, such as
+// https://gist.github.com/user/ID
+func GistID(s string) string {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "/")
+	s = strings.TrimSuffix(s, ".git")
+	if i := strings.LastIndex(s, "/"); i != -1 {
+		s = s[i+1:]
+	}
+	return s
+}
+
+// GetGists returns up to limit gists listed by a given URL, such as
+// octokit.GistsURL for gists of the authenticated user. A limit of zero
+// returns all gists.
+func GetGists(url *octokit.Hyperlink, params octokit.M, limit int) ([]octokit.Gist, error) {
+	client := NewClient()
+	result := []octokit.Gist{}
+
+	gists, resp := client.Gists().All(url, params)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		for _, g := range gists {
+			result = append(result, g)
+			if limit > 0 && len(result) == limit {
+				return result, nil
+			}
+		}
+		if resp.NextPage != nil {
+			gists, resp = client.Gists().All(resp.NextPage, nil)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetGist returns a gist by its ID, optionally at a given revision. An empty
+// revision refers to the latest one.
+func GetGist(id, revision string) (*octokit.Gist, *octokit.Result) {
+	if revision != "" {
+		return NewClient().Gists().One(&octokit.GistsRevisionURL, octokit.M{"gist_id": id, "commit_sha": revision})
+	}
+	return NewClient().Gists().One(&octokit.GistsURL, octokit.M{"gist_id": id})
+}
+
+// GistFileNames returns names of all files of a given gist, sorted
+func GistFileNames(gist *octokit.Gist) []string {
+	names := []string{}
+	for name := range gist.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GistFileContent returns the contents of a gist file. Large files are
+// truncated by the API, in which case their contents are downloaded from
+// their raw URL.
+func GistFileContent(file *octokit.GistFile) (string, error) {
+	if !file.Truncated {
+		return file.Content, nil
+	}
+	req, err := http.NewRequest("GET", file.RawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if token := os.Getenv("GITHUB_ACCESS_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not download %s: %s", file.FileName, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+var githubRemotePattern = regexp.MustCompile(`github\.com[:/]([^/]+)/(.+?)(?:\.git)?/?$`)
+
+// Git runs git with a given set of arguments on the current directory and
+// returns its trimmed output
+func Git(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// CurrentBranch returns the name of the branch checked out on the current
+// directory
+func CurrentBranch() (string, error) {
+	branch, err := Git("symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("could not determine the current branch. Is HEAD detached?")
+	}
+	return branch, nil
+}
+
+// RepoURLFromRemote returns the GitHub repository a given git remote of the
+// current directory points to
+func RepoURLFromRemote(remote string) (*RepoURL, error) {
+	url, err := Git("remote", "get-url", remote)
+	if err != nil {
+		return nil, err
+	}
+	groups := githubRemotePattern.FindStringSubmatch(url)
+	if groups == nil {
+		return nil, fmt.Errorf("remote '%s' does not point to a GitHub repository", remote)
+	}
+	return &RepoURL{Username: groups[1], RepoName: groups[2]}, nil
+}
+
+// RemoteForRepo returns the name of a git remote of the current directory
+// pointing to a given GitHub repository
+func RemoteForRepo(url *RepoURL) (string, bool) {
+	remotes, err := Git("remote")
+	if err != nil {
+		return "", false
+	}
+	for _, remote := range strings.Fields(remotes) {
+		r, err := RepoURLFromRemote(remote)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(r.Username, url.Username) && strings.EqualFold(r.RepoName, url.RepoName) {
+			return remote, true
+		}
+	}
+	return "", false
+}
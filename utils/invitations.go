@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// RepositoryInvitationsURL is the template for pending invitations of a
+	// repository
+	RepositoryInvitationsURL = octokit.Hyperlink("repos/{owner}/{repo}/invitations{/id}")
+
+	// UserInvitationsURL is the template for pending repository invitations
+	// addressed to the authenticated user
+	UserInvitationsURL = octokit.Hyperlink("user/repository_invitations{/id}")
+)
+
+// RepositoryInvitation represents a pending invitation to collaborate on a
+// repository
+type RepositoryInvitation struct {
+	ID          int                 `json:"id,omitempty"`
+	Repository  *octokit.Repository `json:"repository,omitempty"`
+	Invitee     *octokit.User       `json:"invitee,omitempty"`
+	Inviter     *octokit.User       `json:"inviter,omitempty"`
+	Permissions string              `json:"permissions,omitempty"`
+	CreatedAt   *time.Time          `json:"created_at,omitempty"`
+	Expired     bool                `json:"expired,omitempty"`
+	HTMLURL     string              `json:"html_url,omitempty"`
+}
+
+func getAllInvitations(uri *octokit.Hyperlink, params octokit.M) ([]RepositoryInvitation, error) {
+	result := []RepositoryInvitation{}
+
+	invitations := []RepositoryInvitation{}
+	resp := APIRequest("GET", uri, params, nil, &invitations)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, invitations...)
+		if resp.NextPage != nil {
+			invitations = []RepositoryInvitation{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &invitations)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetAllInvitationsForRepo returns a list of pending invitations of a given
+// repository
+func GetAllInvitationsForRepo(url *RepoURL) ([]RepositoryInvitation, error) {
+	return getAllInvitations(&RepositoryInvitationsURL, octokit.M{"owner": url.Username, "repo": url.RepoName})
+}
+
+// GetAllUserInvitations returns a list of pending repository invitations
+// addressed to the authenticated user
+func GetAllUserInvitations() ([]RepositoryInvitation, error) {
+	return getAllInvitations(&UserInvitationsURL, nil)
+}
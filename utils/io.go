@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -33,3 +34,54 @@ ask:
 	fmt.Println("\nHm. Please enter y or n.")
 	goto ask
 }
+
+// ConfirmName asks the user to type a resource name again before a dangerous
+// action takes place. Names are compared ignoring case.
+func ConfirmName(question, name string) bool {
+	fmt.Print(question)
+	reader := bufio.NewReader(os.Stdin)
+	s, err := reader.ReadString('\n')
+	if err != nil {
+		panic(err)
+	}
+
+	s = strings.TrimSpace(s)
+	s = strings.ToLower(s)
+	return s == strings.ToLower(name)
+}
+
+// IsInteractive determines whether the standard input is attached to a
+// terminal
+func IsInteractive() bool {
+	stat, err := os.Stdin.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// Choose shows a numbered list of options and waits for the user to pick one
+// of them. Returns -1 in case the user does not pick any option.
+func Choose(question string, options []string) int {
+	fmt.Println(question)
+	for i, o := range options {
+		fmt.Printf("    %d) %s\n", i+1, o)
+	}
+
+ask:
+
+	fmt.Print("Pick a number, or press enter to skip: ")
+	reader := bufio.NewReader(os.Stdin)
+	s, err := reader.ReadString('\n')
+	if err != nil {
+		panic(err)
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return -1
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > len(options) {
+		fmt.Printf("\nHm. Please enter a number between 1 and %d.\n", len(options))
+		goto ask
+	}
+	return n - 1
+}
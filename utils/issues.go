@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// FilteredRepoIssuesURL is the template for issues of a repository
+	// filtered by state, labels, assignee, milestone or creator
+	FilteredRepoIssuesURL = octokit.Hyperlink("repos/{owner}/{repo}/issues{?state,labels,assignee,milestone,creator,sort,direction}")
+)
+
+// Issue represents an issue along with all users assigned to it
+type Issue struct {
+	octokit.Issue
+	Assignees []octokit.User `json:"assignees,omitempty"`
+}
+
+// IsPullRequest determines whether an issue is actually a pull request, which
+// are also listed by issue endpoints
+func (i *Issue) IsPullRequest() bool {
+	return i.PullRequest.HTMLURL != ""
+}
+
+// GetIssues returns up to limit issues of a given repository matching a set
+// of filters accepted by FilteredRepoIssuesURL. Pull requests are skipped. A
+// limit of zero returns all matching issues.
+func GetIssues(url *RepoURL, filters octokit.M, limit int) ([]Issue, error) {
+	params := octokit.M{"owner": url.Username, "repo": url.RepoName}
+	for k, v := range filters {
+		params[k] = v
+	}
+	result := []Issue{}
+
+	issues := []Issue{}
+	resp := APIRequest("GET", &FilteredRepoIssuesURL, params, nil, &issues)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		for _, i := range issues {
+			if i.IsPullRequest() {
+				continue
+			}
+			result = append(result, i)
+			if limit > 0 && len(result) == limit {
+				return result, nil
+			}
+		}
+		if resp.NextPage != nil {
+			issues = []Issue{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &issues)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetIssue returns a single issue of a given repository
+func GetIssue(url *RepoURL, number int) (*Issue, *octokit.Result) {
+	var issue Issue
+	resp := APIRequest("GET", &octokit.RepoIssuesURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "number": number}, nil, &issue)
+	return &issue, resp
+}
+
+// GetIssueComments returns all comments of a given issue or pull request
+func GetIssueComments(url *RepoURL, number int) ([]octokit.IssueComment, error) {
+	client := NewClient()
+	result := []octokit.IssueComment{}
+
+	comments, resp := client.IssueComments().All(&octokit.IssueCommentsURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "number": number})
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, comments...)
+		if resp.NextPage != nil {
+			comments, resp = client.IssueComments().All(resp.NextPage, nil)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+// sshKeyTypes lists public key types accepted by GitHub
+var sshKeyTypes = map[string]bool{
+	"ssh-rsa":                            true,
+	"ssh-ed25519":                        true,
+	"ecdsa-sha2-nistp256":                true,
+	"ecdsa-sha2-nistp384":                true,
+	"ecdsa-sha2-nistp521":                true,
+	"sk-ssh-ed25519@openssh.com":         true,
+	"sk-ecdsa-sha2-nistp256@openssh.com": true,
+}
+
+// SSHPublicKey represents a public key in the OpenSSH authorized_keys format
+type SSHPublicKey struct {
+	Type    string
+	Blob    []byte
+	Comment string
+}
+
+// ParseSSHPublicKey parses and validates a public key in the OpenSSH
+// authorized_keys format, such as the contents of ~/.ssh/id_ed25519.pub
+func ParseSSHPublicKey(s string) (*SSHPublicKey, error) {
+	fields := strings.Fields(strings.TrimSpace(s))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("not an OpenSSH public key")
+	}
+	if strings.HasPrefix(fields[0], "-----BEGIN") {
+		return nil, fmt.Errorf("this looks like a private key. Please provide its .pub file instead")
+	}
+	if fields[0] == "ssh-dss" {
+		return nil, fmt.Errorf("DSA keys are no longer supported by GitHub")
+	}
+	if !sshKeyTypes[fields[0]] {
+		return nil, fmt.Errorf("unsupported key type '%s'", fields[0])
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed key data")
+	}
+	// The key data begins with its own type, prefixed by its length
+	if len(blob) < 4 {
+		return nil, fmt.Errorf("malformed key data")
+	}
+	n := binary.BigEndian.Uint32(blob)
+	if uint32(len(blob)-4) < n || string(blob[4:4+n]) != fields[0] {
+		return nil, fmt.Errorf("key data does not match its type '%s'", fields[0])
+	}
+	return &SSHPublicKey{Type: fields[0], Blob: blob, Comment: strings.Join(fields[2:], " ")}, nil
+}
+
+// ReadSSHPublicKey reads and validates a public key from a given file
+func ReadSSHPublicKey(path string) (*SSHPublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParseSSHPublicKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return key, nil
+}
+
+// String returns the key in the authorized_keys format, without its comment
+func (k *SSHPublicKey) String() string {
+	return k.Type + " " + base64.StdEncoding.EncodeToString(k.Blob)
+}
+
+// Fingerprint returns the SHA256 fingerprint of the key, as displayed by
+// ssh-keygen -l
+func (k *SSHPublicKey) Fingerprint() string {
+	sum := sha256.Sum256(k.Blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// Equal determines whether two keys are the same, regardless of comments
+func (k *SSHPublicKey) Equal(other *SSHPublicKey) bool {
+	return k.Type == other.Type && bytes.Equal(k.Blob, other.Blob)
+}
+
+// LocalSSHPublicKeys returns paths of public keys found on ~/.ssh, sorted
+func LocalSSHPublicKeys() ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(home, ".ssh", "*.pub"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// GetCurrentUserPublicKeys returns all public SSH keys registered by the
+// authenticated user
+func GetCurrentUserPublicKeys() ([]octokit.Key, error) {
+	client := NewClient()
+	result := []octokit.Key{}
+
+	keys, resp := client.PublicKeys().All(&octokit.CurrentPublicKeyUrl, nil)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, keys...)
+		if resp.NextPage != nil {
+			keys, resp = client.PublicKeys().All(resp.NextPage, nil)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GenerateSSHKeyPair generates an ed25519 key pair without a passphrase using
+// ssh-keygen, writing the private key to a given path and the public key to
+// the same path suffixed with .pub. Existing files are never overwritten.
+func GenerateSSHKeyPair(path, comment string) (*SSHPublicKey, error) {
+	for _, p := range []string{path, path + ".pub"} {
+		if _, err := os.Stat(p); err == nil {
+			return nil, fmt.Errorf("%s already exists", p)
+		}
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", comment, "-f", path)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("ssh-keygen: %s", msg)
+		}
+		return nil, fmt.Errorf("ssh-keygen: %s", err)
+	}
+	return ReadSSHPublicKey(path + ".pub")
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var labelColorPattern = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// Label represents a repository label along with its description, which is
+// not covered by octokit.Label
+type Label struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// LabelColor normalizes a label color given as a hexadecimal RGB value,
+// optionally prefixed by #
+func LabelColor(color string) (string, error) {
+	c := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(color), "#"))
+	if !labelColorPattern.MatchString(c) {
+		return "", fmt.Errorf("'%s' is not a valid color. Please use a hexadecimal value such as d73a4a", color)
+	}
+	return c, nil
+}
+
+// GetAllLabels returns all labels of a given repository
+func GetAllLabels(url *RepoURL) ([]Label, error) {
+	result := []Label{}
+
+	labels := []Label{}
+	resp := APIRequest("GET", &octokit.RepoLabelsURL, octokit.M{"owner": url.Username, "repo": url.RepoName}, nil, &labels)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, labels...)
+		if resp.NextPage != nil {
+			labels = []Label{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &labels)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ReadLabelsFile reads a list of labels from a JSON file, or a YAML file
+// containing a sequence of mappings with name, color and description keys:
+//
+//   - name: bug
+//     color: d73a4a
+//     description: Something isn't working
+//
+// Only that subset of YAML is supported.
+func ReadLabelsFile(path string) ([]Label, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []Label{}
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		if err := json.Unmarshal(data, &labels); err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+	} else if labels, err = parseLabelsYAML(string(data)); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	for i, l := range labels {
+		if strings.TrimSpace(l.Name) == "" {
+			return nil, fmt.Errorf("%s: label #%d has no name", path, i+1)
+		}
+		if labels[i].Color, err = LabelColor(l.Color); err != nil {
+			return nil, fmt.Errorf("%s: label %s: %s", path, l.Name, err)
+		}
+	}
+	return labels, nil
+}
+
+// parseLabelsYAML parses the YAML subset described by ReadLabelsFile
+func parseLabelsYAML(data string) ([]Label, error) {
+	labels := []Label{}
+	for n, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			labels = append(labels, Label{})
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			if trimmed == "" {
+				continue
+			}
+		} else if len(labels) == 0 || line == trimmed {
+			return nil, fmt.Errorf("line %d: expected a list of labels", n+1)
+		}
+
+		idx := strings.Index(trimmed, ":")
+		if idx == -1 {
+			return nil, fmt.Errorf("line %d: expected a key and a value", n+1)
+		}
+		key := strings.TrimSpace(trimmed[:idx])
+		value := yamlScalar(strings.TrimSpace(trimmed[idx+1:]))
+		label := &labels[len(labels)-1]
+		switch key {
+		case "name":
+			label.Name = value
+		case "color":
+			label.Color = value
+		case "description":
+			label.Description = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown key '%s'", n+1, key)
+		}
+	}
+	return labels, nil
+}
+
+// yamlScalar unquotes a YAML scalar, stripping trailing comments from plain
+// ones
+func yamlScalar(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		var v string
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			return v
+		}
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1)
+	}
+	if idx := strings.Index(s, " #"); idx > -1 {
+		s = s[:idx]
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseLabelsYAML(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want []Label
+	}{
+		{
+			name: "valid",
+			data: "- name: bug\n  color: d73a4a\n  description: Something isn't working\n- name: docs\n  color: 0075ca\n",
+			want: []Label{
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+				{Name: "docs", Color: "0075ca"},
+			},
+		},
+		{
+			name: "document marker, comments and blank lines",
+			data: "---\n# Labels\n\n- name: bug # a comment\n  color: d73a4a\n\n  # another comment\n  description: Broken\n",
+			want: []Label{{Name: "bug", Color: "d73a4a", Description: "Broken"}},
+		},
+		{
+			name: "double quoted",
+			data: "- name: \"good first issue\"\n  color: \"#7057ff\"\n  description: \"Tabs\\tand # hashes\"\n",
+			want: []Label{{Name: "good first issue", Color: "#7057ff", Description: "Tabs\tand # hashes"}},
+		},
+		{
+			name: "single quoted",
+			data: "- name: 'won''t fix'\n  color: 'ffffff'\n  description: 'Not # a comment'\n",
+			want: []Label{{Name: "won't fix", Color: "ffffff", Description: "Not # a comment"}},
+		},
+		{
+			name: "dash on its own line",
+			data: "-\n  name: bug\n  color: d73a4a\n",
+			want: []Label{{Name: "bug", Color: "d73a4a"}},
+		},
+		{
+			name: "empty",
+			data: "",
+			want: []Label{},
+		},
+	}
+	for _, c := range cases {
+		got, err := parseLabelsYAML(c.data)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseLabelsYAMLMalformed(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+	}{
+		{"mapping instead of list", "name: bug\ncolor: d73a4a\n"},
+		{"key outside of an item", "- name: bug\ncolor: d73a4a\n"},
+		{"missing value separator", "- name bug\n"},
+		{"unknown key", "- name: bug\n  colour: d73a4a\n"},
+	}
+	for _, c := range cases {
+		if labels, err := parseLabelsYAML(c.data); err == nil {
+			t.Errorf("%s: expected an error, got %+v", c.name, labels)
+		}
+	}
+}
+
+func TestYAMLScalar(t *testing.T) {
+	cases := map[string]string{
+		"plain":                 "plain",
+		"plain # comment":       "plain",
+		"issue#1":               "issue#1",
+		`"quoted # text"`:       "quoted # text",
+		`"escaped \"quotes\""`:  `escaped "quotes"`,
+		`'single ''quoted'''`:   "single 'quoted'",
+		`"unterminated`:         `"unterminated`,
+		`"`:                     `"`,
+		"":                      "",
+		"trailing spaces   # x": "trailing spaces",
+	}
+	for in, want := range cases {
+		if got := yamlScalar(in); got != want {
+			t.Errorf("yamlScalar(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLabelColor(t *testing.T) {
+	valid := map[string]string{
+		"d73a4a":    "d73a4a",
+		"#D73A4A":   "d73a4a",
+		" 0075ca\n": "0075ca",
+	}
+	for in, want := range valid {
+		got, err := LabelColor(in)
+		if err != nil || got != want {
+			t.Errorf("LabelColor(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "red", "d73a4", "d73a4a0", "##d73a4a", "g73a4a"} {
+		if _, err := LabelColor(in); err == nil {
+			t.Errorf("LabelColor(%q): expected an error", in)
+		}
+	}
+}
+
+func TestReadLabelsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"labels.json": `[{"name": "bug", "color": "#D73A4A", "description": "Broken"}]`,
+		"labels.yml":  "- name: bug\n  color: \"#D73A4A\"\n  description: Broken\n",
+	}
+	want := []Label{{Name: "bug", Color: "d73a4a", Description: "Broken"}}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadLabelsFile(path)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", name, got, want)
+		}
+	}
+
+	invalid := map[string]string{
+		"malformed.json": `[{"name": "bug"`,
+		"noname.yml":     "- color: d73a4a\n",
+		"nocolor.yml":    "- name: bug\n",
+		"badcolor.json":  `[{"name": "bug", "color": "red"}]`,
+	}
+	for name, data := range invalid {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if labels, err := ReadLabelsFile(path); err == nil {
+			t.Errorf("%s: expected an error, got %+v", name, labels)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// RepoMilestonesURL is the template for milestones of a repository,
+	// optionally filtered by state (open, closed or all)
+	RepoMilestonesURL = octokit.Hyperlink("repos/{owner}/{repo}/milestones{/number}{?state}")
+)
+
+// GetAllMilestones returns a list of milestones of a given repository under a
+// given state. An empty state lists open milestones.
+func GetAllMilestones(url *RepoURL, state string) ([]octokit.Milestone, error) {
+	params := octokit.M{"owner": url.Username, "repo": url.RepoName}
+	if state != "" {
+		params["state"] = state
+	}
+	result := []octokit.Milestone{}
+
+	milestones := []octokit.Milestone{}
+	resp := APIRequest("GET", &RepoMilestonesURL, params, nil, &milestones)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, milestones...)
+		if resp.NextPage != nil {
+			milestones = []octokit.Milestone{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &milestones)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// FindMilestone returns a milestone of a given repository identified either
+// by its number or its title
+func FindMilestone(url *RepoURL, milestone string) (*octokit.Milestone, error) {
+	milestones, err := GetAllMilestones(url, "all")
+	if err != nil {
+		return nil, err
+	}
+	number, _ := strconv.Atoi(strings.TrimPrefix(milestone, "#"))
+	for _, m := range milestones {
+		if m.Number == number || strings.EqualFold(m.Title, milestone) {
+			m := m
+			return &m, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find a milestone named '%s' on %s", milestone, url.ToURL())
+}
@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// OrganizationMembershipURL is the template for a user's membership on an
+	// organization
+	OrganizationMembershipURL = octokit.Hyperlink("orgs/{org}/memberships/{username}")
+
+	// FilteredOrganizationMembersURL is the template for members of an
+	// organization filtered by role or by two-factor authentication status
+	FilteredOrganizationMembersURL = octokit.Hyperlink("orgs/{org}/members{?filter,role}")
+
+	// OrganizationInvitationsURL is the template for pending invitations to
+	// join an organization
+	OrganizationInvitationsURL = octokit.Hyperlink("orgs/{org}/invitations{/id}")
+
+	// OutsideCollaboratorsURL is the template for users with access to
+	// organization repositories who are not members of the organization
+	OutsideCollaboratorsURL = octokit.Hyperlink("orgs/{org}/outside_collaborators{/username}")
+)
+
+// OrgMembership represents a user's membership on an organization
+type OrgMembership struct {
+	URL   string        `json:"url,omitempty"`
+	State string        `json:"state,omitempty"`
+	Role  string        `json:"role,omitempty"`
+	User  *octokit.User `json:"user,omitempty"`
+}
+
+// GetOrgMembership returns a user's membership on a given organization, or
+// nil in case the user is not a member
+func GetOrgMembership(org, username string) (*OrgMembership, error) {
+	var membership OrgMembership
+	resp := APIRequest("GET", &OrganizationMembershipURL, octokit.M{"org": org, "username": username}, nil, &membership)
+	if IsNotFound(resp) {
+		return nil, nil
+	}
+	if resp.HasError() {
+		return nil, resp.Err
+	}
+	return &membership, nil
+}
+
+// OrgPlan represents the billing plan of an organization
+type OrgPlan struct {
+	Name         string `json:"name,omitempty"`
+	Space        int    `json:"space,omitempty"`
+	PrivateRepos int    `json:"private_repos,omitempty"`
+	Seats        int    `json:"seats,omitempty"`
+	FilledSeats  int    `json:"filled_seats,omitempty"`
+}
+
+// OrgDetails represents an organization along with settings only visible to
+// its owners
+type OrgDetails struct {
+	octokit.Organization
+	Plan                                *OrgPlan `json:"plan,omitempty"`
+	DefaultRepositoryPermission         string   `json:"default_repository_permission,omitempty"`
+	TwoFactorRequirementEnabled         *bool    `json:"two_factor_requirement_enabled,omitempty"`
+	MembersCanCreateRepositories        *bool    `json:"members_can_create_repositories,omitempty"`
+	MembersCanCreatePublicRepositories  *bool    `json:"members_can_create_public_repositories,omitempty"`
+	MembersCanCreatePrivateRepositories *bool    `json:"members_can_create_private_repositories,omitempty"`
+}
+
+// GetOrgDetails returns details of a given organization
+func GetOrgDetails(org string) (*OrgDetails, error) {
+	var details OrgDetails
+	resp := APIRequest("GET", &octokit.OrganizationURL, octokit.M{"org": org}, nil, &details)
+	if resp.HasError() {
+		return nil, resp.Err
+	}
+	return &details, nil
+}
+
+// OrgInvitation represents a pending invitation to join an organization
+type OrgInvitation struct {
+	ID        int           `json:"id,omitempty"`
+	Login     string        `json:"login,omitempty"`
+	Email     string        `json:"email,omitempty"`
+	Role      string        `json:"role,omitempty"`
+	CreatedAt *time.Time    `json:"created_at,omitempty"`
+	Inviter   *octokit.User `json:"inviter,omitempty"`
+	TeamCount int           `json:"team_count,omitempty"`
+}
+
+func getAllUsers(uri *octokit.Hyperlink, params octokit.M) ([]octokit.User, error) {
+	result := []octokit.User{}
+
+	users := []octokit.User{}
+	resp := APIRequest("GET", uri, params, nil, &users)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, users...)
+		if resp.NextPage != nil {
+			users = []octokit.User{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &users)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+func getAllOrgInvitations(uri *octokit.Hyperlink, params octokit.M) ([]OrgInvitation, error) {
+	result := []OrgInvitation{}
+
+	invitations := []OrgInvitation{}
+	resp := APIRequest("GET", uri, params, nil, &invitations)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, invitations...)
+		if resp.NextPage != nil {
+			invitations = []OrgInvitation{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &invitations)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetFilteredUsersForOrg returns a list of users that belongs to an
+// organization, filtered by role (admin, member or all) and by two-factor
+// authentication status (2fa_disabled or all). Empty values are ignored.
+func GetFilteredUsersForOrg(org, role, filter string) ([]octokit.User, error) {
+	params := octokit.M{"org": org}
+	if role != "" {
+		params["role"] = role
+	}
+	if filter != "" {
+		params["filter"] = filter
+	}
+	return getAllUsers(&FilteredOrganizationMembersURL, params)
+}
+
+// GetAllInvitationsForOrg returns a list of pending invitations to join a
+// given organization
+func GetAllInvitationsForOrg(org string) ([]OrgInvitation, error) {
+	return getAllOrgInvitations(&OrganizationInvitationsURL, octokit.M{"org": org})
+}
+
+// GetOutsideCollaborators returns a list of users with access to repositories
+// of a given organization who are not members of it
+func GetOutsideCollaborators(org string) ([]octokit.User, error) {
+	return getAllUsers(&OutsideCollaboratorsURL, octokit.M{"org": org})
+}
+
+// GetUserTeams returns all teams of an organization a given user belongs to
+func GetUserTeams(org, username string) ([]NestedTeam, error) {
+	teams, err := GetAllNestedTeamsForOrg(org)
+	if err != nil {
+		return nil, err
+	}
+	result := []NestedTeam{}
+	for _, t := range teams {
+		isMember, err := IsTeamMember(t.ID, username)
+		if err != nil {
+			return nil, err
+		}
+		if isMember {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// GetAllReposForOrg returns a list of all repositories of a given
+// organization
+func GetAllReposForOrg(org string) ([]octokit.Repository, error) {
+	client := NewClient()
+	result := []octokit.Repository{}
+
+	repos, resp := client.Repositories().All(&octokit.OrgRepositoriesURL, octokit.M{"org": org})
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, repos...)
+		if resp.NextPage != nil {
+			repos, resp = client.Repositories().All(resp.NextPage, nil)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+// PagesSource represents the branch and directory a Pages site is built from
+type PagesSource struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
+}
+
+// PagesSite represents a Pages site along with its source, which is not
+// covered by octokit.PageInfo
+type PagesSite struct {
+	URL       string       `json:"url"`
+	HTMLURL   string       `json:"html_url"`
+	Status    string       `json:"status"`
+	Cname     string       `json:"cname"`
+	Custom404 bool         `json:"custom_404"`
+	Source    *PagesSource `json:"source"`
+}
+
+// GetPagesSite returns the Pages site of a given repository
+func GetPagesSite(url *RepoURL) (*PagesSite, *octokit.Result) {
+	var site PagesSite
+	resp := APIRequest("GET", &octokit.PagesURL, octokit.M{"owner": url.Username, "repo": url.RepoName}, nil, &site)
+	return &site, resp
+}
+
+// GetPagesBuilds returns up to limit builds of the Pages site of a given
+// repository, newest first. A limit of zero returns all builds.
+func GetPagesBuilds(url *RepoURL, limit int) ([]octokit.PageBuild, error) {
+	result := []octokit.PageBuild{}
+
+	builds := []octokit.PageBuild{}
+	resp := APIRequest("GET", &octokit.PagesBuildsURL, octokit.M{"owner": url.Username, "repo": url.RepoName}, nil, &builds)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		for _, b := range builds {
+			result = append(result, b)
+			if limit > 0 && len(result) == limit {
+				return result, nil
+			}
+		}
+		if resp.NextPage != nil {
+			builds = []octokit.PageBuild{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &builds)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
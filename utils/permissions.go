@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// CollaboratorPermissionURL is the template for a user's effective
+	// permission on a repository
+	CollaboratorPermissionURL = octokit.Hyperlink("repos/{owner}/{repo}/collaborators/{username}/permission")
+
+	// AffiliatedCollaboratorsURL is the template for a repository's
+	// collaborators filtered by their affiliation (outside, direct or all)
+	AffiliatedCollaboratorsURL = octokit.Hyperlink("repos/{owner}/{repo}/collaborators{?affiliation}")
+
+	// CustomRepositoryRolesURL is the template for custom repository roles
+	// defined by an organization
+	CustomRepositoryRolesURL = octokit.Hyperlink("orgs/{org}/custom-repository-roles")
+)
+
+// RepoPermissions represents the set of permissions a user holds on a
+// repository
+type RepoPermissions struct {
+	Admin    bool `json:"admin"`
+	Maintain bool `json:"maintain"`
+	Push     bool `json:"push"`
+	Triage   bool `json:"triage"`
+	Pull     bool `json:"pull"`
+}
+
+// Collaborator represents a repository collaborator along with the role they
+// hold on it
+type Collaborator struct {
+	octokit.User
+	Permissions *RepoPermissions `json:"permissions,omitempty"`
+	RoleName    string           `json:"role_name,omitempty"`
+}
+
+// Highest returns the highest permission level in the set
+func (p *RepoPermissions) Highest() string {
+	switch {
+	case p == nil:
+		return "none"
+	case p.Admin:
+		return "admin"
+	case p.Maintain:
+		return "maintain"
+	case p.Push:
+		return "write"
+	case p.Triage:
+		return "triage"
+	case p.Pull:
+		return "read"
+	}
+	return "none"
+}
+
+// Role returns the name of the role held by the collaborator, falling back to
+// the highest permission they have when the API does not provide one
+func (c *Collaborator) Role() string {
+	if c.RoleName != "" {
+		return c.RoleName
+	}
+	return c.Permissions.Highest()
+}
+
+// CustomRepositoryRole represents a repository role defined by an
+// organization
+type CustomRepositoryRole struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	BaseRole    string `json:"base_role,omitempty"`
+}
+
+// CollaboratorPermission represents the effective permission a user holds on
+// a repository
+type CollaboratorPermission struct {
+	Permission string        `json:"permission,omitempty"`
+	RoleName   string        `json:"role_name,omitempty"`
+	User       *octokit.User `json:"user,omitempty"`
+}
+
+var permissionRanks = map[string]int{
+	"none":     0,
+	"read":     1,
+	"pull":     1,
+	"triage":   2,
+	"write":    3,
+	"push":     3,
+	"maintain": 4,
+	"admin":    5,
+}
+
+var permissionAliases = map[string]string{
+	"read":  "pull",
+	"write": "push",
+}
+
+// PermissionRank returns a value that allows permission levels to be compared
+// regardless of whether they use the read/write or pull/push naming
+func PermissionRank(permission string) int {
+	return permissionRanks[permission]
+}
+
+// GetCollaboratorPermission returns the effective permission of a given user
+// on a repository
+func GetCollaboratorPermission(url *RepoURL, username string) (*CollaboratorPermission, *octokit.Result) {
+	var perm CollaboratorPermission
+	resp := APIRequest("GET", &CollaboratorPermissionURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "username": username}, nil, &perm)
+	return &perm, resp
+}
+
+// GetCustomRepositoryRoles returns a list of custom repository roles defined
+// by a given organization. Organizations without access to custom roles yield
+// an empty list.
+func GetCustomRepositoryRoles(org string) ([]CustomRepositoryRole, error) {
+	var roles struct {
+		CustomRoles []CustomRepositoryRole `json:"custom_roles"`
+	}
+	resp := APIRequest("GET", &CustomRepositoryRolesURL, octokit.M{"org": org}, nil, &roles)
+	if IsNotFound(resp) {
+		return nil, nil
+	}
+	if resp.HasError() {
+		return nil, resp.Err
+	}
+	return roles.CustomRoles, nil
+}
+
+// ParsePermission normalizes a permission level provided by the user into one
+// accepted by the API. Besides pull/read, triage, push/write, maintain and
+// admin, custom roles defined by org are also accepted when org is not empty.
+func ParsePermission(permission, org string) (string, error) {
+	permission = strings.ToLower(permission)
+	if v, present := permissionAliases[permission]; present {
+		permission = v
+	}
+	if _, present := permissionRanks[permission]; present && permission != "none" {
+		return permission, nil
+	}
+	if org != "" {
+		roles, err := GetCustomRepositoryRoles(org)
+		if err != nil {
+			return "", err
+		}
+		for _, r := range roles {
+			if strings.EqualFold(r.Name, permission) {
+				return r.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("incorrect permission %s: valid permissions are pull/read, triage, push/write, maintain, admin and custom roles defined by the organization", permission)
+}
+
+// InvitationPermission converts a permission level into the naming used by
+// repository invitations
+func InvitationPermission(permission string) string {
+	for alias, v := range permissionAliases {
+		if v == permission {
+			return alias
+		}
+	}
+	return permission
+}
+
+// permissionInput returns the body of a request granting a permission level,
+// leaving it empty so defaults apply when no level is given
+func permissionInput(permission string) interface{} {
+	if permission == "" {
+		return nil
+	}
+	return octokit.M{"permission": permission}
+}
+
+// SetTeamRepoPermission grants a team a permission level on a repository,
+// either adding it to the repository or changing its current level
+func SetTeamRepoPermission(teamID int, url *RepoURL, permission string) *octokit.Result {
+	return APIRequest("PUT", &octokit.TeamRepositoryURL, octokit.M{"id": teamID, "owner": url.Username, "repo": url.RepoName}, permissionInput(permission), nil)
+}
+
+// RemoveTeamRepo revokes a team's access to a repository
+func RemoveTeamRepo(teamID int, url *RepoURL) *octokit.Result {
+	return APIRequest("DELETE", &octokit.TeamRepositoryURL, octokit.M{"id": teamID, "owner": url.Username, "repo": url.RepoName}, nil, nil)
+}
+
+// SetCollaboratorPermission adds a collaborator to a repository under a
+// permission level, or changes their current level
+func SetCollaboratorPermission(url *RepoURL, username, permission string) *octokit.Result {
+	return APIRequest("PUT", &octokit.CollaboratorsURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "username": username}, permissionInput(permission), nil)
+}
+
+// RemoveCollaborator removes a collaborator from a repository
+func RemoveCollaborator(url *RepoURL, username string) *octokit.Result {
+	return APIRequest("DELETE", &octokit.CollaboratorsURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "username": username}, nil, nil)
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"io"
+	"time"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// FilteredPullRequestsURL is the template for pull requests of a
+	// repository filtered by state, head or base branches
+	FilteredPullRequestsURL = octokit.Hyperlink("repos/{owner}/{repo}/pulls{?state,head,base,sort,direction}")
+
+	// PullRequestFilesURL is the template for files changed by a pull request
+	PullRequestFilesURL = octokit.Hyperlink("repos/{owner}/{repo}/pulls/{number}/files")
+
+	// PullRequestReviewsURL is the template for reviews of a pull request
+	PullRequestReviewsURL = octokit.Hyperlink("repos/{owner}/{repo}/pulls/{number}/reviews")
+
+	// PullRequestMergeURL is the template used to merge a pull request
+	PullRequestMergeURL = octokit.Hyperlink("repos/{owner}/{repo}/pulls/{number}/merge")
+
+	// GitRefURL is the template for a git reference of a repository
+	GitRefURL = octokit.Hyperlink("repos/{owner}/{repo}/git/refs/{+ref}")
+)
+
+// PullRequest represents a pull request along with its draft state and
+// requested reviewers
+type PullRequest struct {
+	octokit.PullRequest
+	Draft              bool           `json:"draft,omitempty"`
+	RequestedReviewers []octokit.User `json:"requested_reviewers,omitempty"`
+	RequestedTeams     []octokit.Team `json:"requested_teams,omitempty"`
+}
+
+// Status returns a short description of the state of a pull request
+func (p *PullRequest) Status() string {
+	switch {
+	case p.Merged || p.MergedAt != nil:
+		return "merged"
+	case p.State == "open" && p.Draft:
+		return "draft"
+	}
+	return p.State
+}
+
+// PullRequestFile represents a file changed by a pull request
+type PullRequestFile struct {
+	Filename  string `json:"filename,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Additions int    `json:"additions,omitempty"`
+	Deletions int    `json:"deletions,omitempty"`
+	Changes   int    `json:"changes,omitempty"`
+}
+
+// PullRequestReview represents a review submitted to a pull request
+type PullRequestReview struct {
+	ID          int          `json:"id,omitempty"`
+	User        octokit.User `json:"user,omitempty"`
+	State       string       `json:"state,omitempty"`
+	SubmittedAt *time.Time   `json:"submitted_at,omitempty"`
+}
+
+// RepositoryDefaults represents settings of a repository not exposed by
+// octokit.Repository
+type RepositoryDefaults struct {
+	DefaultBranch string `json:"default_branch,omitempty"`
+}
+
+// GetDefaultBranch returns the default branch of a given repository
+func GetDefaultBranch(url *RepoURL) (string, error) {
+	var repo RepositoryDefaults
+	resp := APIRequest("GET", &octokit.RepositoryURL, octokit.M{"owner": url.Username, "repo": url.RepoName}, nil, &repo)
+	if resp.HasError() {
+		return "", resp.Err
+	}
+	return repo.DefaultBranch, nil
+}
+
+// GetPullRequests returns up to limit pull requests of a given repository
+// matching a set of filters accepted by FilteredPullRequestsURL. A limit of
+// zero returns all matching pull requests.
+func GetPullRequests(url *RepoURL, filters octokit.M, limit int) ([]PullRequest, error) {
+	params := octokit.M{"owner": url.Username, "repo": url.RepoName}
+	for k, v := range filters {
+		params[k] = v
+	}
+	result := []PullRequest{}
+
+	pulls := []PullRequest{}
+	resp := APIRequest("GET", &FilteredPullRequestsURL, params, nil, &pulls)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		for _, p := range pulls {
+			result = append(result, p)
+			if limit > 0 && len(result) == limit {
+				return result, nil
+			}
+		}
+		if resp.NextPage != nil {
+			pulls = []PullRequest{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &pulls)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetPullRequest returns a single pull request of a given repository
+func GetPullRequest(url *RepoURL, number int) (*PullRequest, *octokit.Result) {
+	var pull PullRequest
+	resp := APIRequest("GET", &octokit.PullRequestsURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "number": number}, nil, &pull)
+	return &pull, resp
+}
+
+// GetPullRequestDiff returns the diff of a given pull request. The caller is
+// responsible for closing it.
+func GetPullRequestDiff(url *RepoURL, number int) (io.ReadCloser, *octokit.Result) {
+	u, err := octokit.PullRequestsURL.Expand(octokit.M{"owner": url.Username, "repo": url.RepoName, "number": number})
+	if err != nil {
+		return nil, &octokit.Result{Err: err}
+	}
+	return NewClient().PullRequests(u).Diff()
+}
+
+// GetPullRequestFiles returns all files changed by a given pull request
+func GetPullRequestFiles(url *RepoURL, number int) ([]PullRequestFile, error) {
+	result := []PullRequestFile{}
+
+	files := []PullRequestFile{}
+	resp := APIRequest("GET", &PullRequestFilesURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "number": number}, nil, &files)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, files...)
+		if resp.NextPage != nil {
+			files = []PullRequestFile{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &files)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetPullRequestReviews returns all reviews submitted to a given pull request
+func GetPullRequestReviews(url *RepoURL, number int) ([]PullRequestReview, error) {
+	result := []PullRequestReview{}
+
+	reviews := []PullRequestReview{}
+	resp := APIRequest("GET", &PullRequestReviewsURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "number": number}, nil, &reviews)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, reviews...)
+		if resp.NextPage != nil {
+			reviews = []PullRequestReview{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &reviews)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// ReleaseByTagURL is the template for a release identified by its tag
+	ReleaseByTagURL = octokit.Hyperlink("repos/{owner}/{repo}/releases/tags/{tag}")
+)
+
+// GetAllReleases returns a list of all releases of a given repository,
+// newest first
+func GetAllReleases(url *RepoURL) ([]octokit.Release, error) {
+	result := []octokit.Release{}
+
+	releases := []octokit.Release{}
+	resp := APIRequest("GET", &octokit.ReleasesURL, octokit.M{"owner": url.Username, "repo": url.RepoName}, nil, &releases)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, releases...)
+		if resp.NextPage != nil {
+			releases = []octokit.Release{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &releases)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetRelease returns a release of a given repository identified by its tag.
+// The "latest" tag refers to the latest published release.
+func GetRelease(url *RepoURL, tag string) (*octokit.Release, *octokit.Result) {
+	var release octokit.Release
+	params := octokit.M{"owner": url.Username, "repo": url.RepoName, "tag": tag}
+	if tag == "latest" {
+		resp := APIRequest("GET", &octokit.ReleasesLatestURL, params, nil, &release)
+		return &release, resp
+	}
+	resp := APIRequest("GET", &ReleaseByTagURL, params, nil, &release)
+	if !IsNotFound(resp) {
+		return &release, resp
+	}
+
+	// Drafts are not associated with tags until published, so they can only
+	// be found by listing all releases.
+	releases, err := GetAllReleases(url)
+	if err != nil {
+		return nil, &octokit.Result{Err: err}
+	}
+	for _, r := range releases {
+		if r.TagName == tag {
+			return &r, &octokit.Result{}
+		}
+	}
+	return nil, resp
+}
+
+// assetContentType guesses the content type of a file based on its
+// extension, falling back to sniffing its contents
+func assetContentType(f *os.File) (string, error) {
+	if t := mime.TypeByExtension(filepath.Ext(f.Name())); t != "" {
+		return t, nil
+	}
+	buf := make([]byte, 512)
+	n, err := f.Read(buf)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// UploadReleaseAsset uploads a file as an asset of a given release
+func UploadReleaseAsset(release *octokit.Release, path string) *octokit.Result {
+	f, err := os.Open(path)
+	if err != nil {
+		return &octokit.Result{Err: err}
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return &octokit.Result{Err: err}
+	}
+	contentType, err := assetContentType(f)
+	if err != nil {
+		f.Close()
+		return &octokit.Result{Err: err}
+	}
+	url, err := release.UploadURL.Expand(octokit.M{"name": filepath.Base(path)})
+	if err != nil {
+		f.Close()
+		return &octokit.Result{Err: err}
+	}
+	// The request body is closed once the upload completes
+	return NewClient().Uploads(url).UploadAsset(f, contentType, stat.Size())
+}
+
+// DownloadReleaseAsset writes the contents of a release asset to a given
+// writer. Assets are requested through the API so private repositories are
+// supported.
+func DownloadReleaseAsset(asset *octokit.Asset, w io.Writer) error {
+	req, err := http.NewRequest("GET", asset.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	if token := os.Getenv("GITHUB_ACCESS_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not download %s: %s", asset.Name, resp.Status)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+// searchPage fetches a page of search results from a given URL, returning
+// how many items it contained
+type searchPage func(uri *octokit.Hyperlink, params octokit.M) (int, *octokit.Result)
+
+// searchAll follows pages of search results until a given limit of items is
+// reached, or results are exhausted. The API never returns more than 1000
+// results for a single query.
+func searchAll(uri *octokit.Hyperlink, query, sort, order string, limit int, fetch searchPage) error {
+	params := octokit.M{"query": query, "per_page": 100}
+	if limit > 0 && limit < 100 {
+		params["per_page"] = limit
+	}
+	if sort != "" {
+		params["sort"] = sort
+	}
+	if order != "" {
+		params["order"] = order
+	}
+
+	count := 0
+	n, resp := fetch(uri, params)
+	for {
+		if resp.HasError() {
+			return resp.Err
+		}
+		count += n
+		if n == 0 || resp.NextPage == nil || (limit > 0 && count >= limit) {
+			break
+		}
+		n, resp = fetch(resp.NextPage, nil)
+	}
+	return nil
+}
+
+// SearchRepositories returns up to limit repositories matching a given query.
+// A limit of zero returns all matching repositories.
+func SearchRepositories(query, sort, order string, limit int) ([]octokit.Repository, int, error) {
+	client := NewClient()
+	result := []octokit.Repository{}
+	total := 0
+	err := searchAll(&octokit.RepositorySearchURL, query, sort, order, limit, func(uri *octokit.Hyperlink, params octokit.M) (int, *octokit.Result) {
+		page, resp := client.Search().Repositories(uri, params)
+		if resp.HasError() {
+			return 0, resp
+		}
+		total = page.TotalCount
+		result = append(result, page.Items...)
+		return len(page.Items), resp
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, total, err
+}
+
+// SearchIssues returns up to limit issues and pull requests matching a given
+// query. A limit of zero returns all matching issues.
+func SearchIssues(query, sort, order string, limit int) ([]octokit.Issue, int, error) {
+	client := NewClient()
+	result := []octokit.Issue{}
+	total := 0
+	err := searchAll(&octokit.IssueSearchURL, query, sort, order, limit, func(uri *octokit.Hyperlink, params octokit.M) (int, *octokit.Result) {
+		page, resp := client.Search().Issues(uri, params)
+		if resp.HasError() {
+			return 0, resp
+		}
+		total = page.TotalCount
+		result = append(result, page.Items...)
+		return len(page.Items), resp
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, total, err
+}
+
+// SearchCode returns up to limit files matching a given query. A limit of
+// zero returns all matching files.
+func SearchCode(query, sort, order string, limit int) ([]octokit.CodeFile, int, error) {
+	client := NewClient()
+	result := []octokit.CodeFile{}
+	total := 0
+	err := searchAll(&octokit.CodeSearchURL, query, sort, order, limit, func(uri *octokit.Hyperlink, params octokit.M) (int, *octokit.Result) {
+		page, resp := client.Search().Code(uri, params)
+		if resp.HasError() {
+			return 0, resp
+		}
+		total = page.TotalCount
+		result = append(result, page.Items...)
+		return len(page.Items), resp
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, total, err
+}
+
+// SearchUsers returns up to limit users and organizations matching a given
+// query. A limit of zero returns all matching users.
+func SearchUsers(query, sort, order string, limit int) ([]octokit.User, int, error) {
+	client := NewClient()
+	result := []octokit.User{}
+	total := 0
+	err := searchAll(&octokit.UserSearchURL, query, sort, order, limit, func(uri *octokit.Hyperlink, params octokit.M) (int, *octokit.Result) {
+		page, resp := client.Search().Users(uri, params)
+		if resp.HasError() {
+			return 0, resp
+		}
+		total = page.TotalCount
+		result = append(result, page.Items...)
+		return len(page.Items), resp
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, total, err
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// CombinedStatusURL is the template for the combined status of all
+	// contexts of a given branch, tag or SHA
+	CombinedStatusURL = octokit.Hyperlink("repos/{owner}/{repo}/commits/{ref}/status{?per_page}")
+)
+
+// CombinedStatus represents the overall state of a commit, along with the
+// latest status of each of its contexts
+type CombinedStatus struct {
+	State      string           `json:"state"`
+	Sha        string           `json:"sha"`
+	TotalCount int              `json:"total_count"`
+	Statuses   []octokit.Status `json:"statuses"`
+}
+
+// GetCombinedStatus returns the combined status of a given branch, tag or SHA
+func GetCombinedStatus(url *RepoURL, ref string) (*CombinedStatus, *octokit.Result) {
+	var status CombinedStatus
+	resp := APIRequest("GET", &CombinedStatusURL, octokit.M{"owner": url.Username, "repo": url.RepoName, "ref": ref, "per_page": 100}, nil, &status)
+	return &status, resp
+}
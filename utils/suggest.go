@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the maximum number of suggestions shown when a name
+// cannot be found
+const maxSuggestions = 5
+
+// editDistance returns the Levenshtein distance between two strings,
+// ignoring case
+func editDistance(a, b string) int {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j] + 1
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// ClosestMatches returns candidates resembling a given name, closest first.
+// Candidates containing the name, or within a few typos of it, are
+// considered.
+func ClosestMatches(name string, candidates []string) []string {
+	type match struct {
+		value    string
+		distance int
+	}
+	lowerName := strings.ToLower(name)
+	threshold := len(name)/3 + 1
+	matches := []match{}
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		lower := strings.ToLower(c)
+		if seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		d := editDistance(name, c)
+		if d <= threshold || strings.Contains(lower, lowerName) {
+			matches = append(matches, match{c, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+	result := []string{}
+	for _, m := range matches {
+		result = append(result, m.value)
+	}
+	return result
+}
+
+// Suggest looks for candidates resembling a name that could not be found.
+// When running interactively, the user is asked to pick one of them;
+// otherwise they are only listed. Returns the picked candidate, or an empty
+// string in case none was picked.
+func Suggest(name string, candidates []string) string {
+	matches := ClosestMatches(name, candidates)
+	if len(matches) == 0 {
+		return ""
+	}
+	if !IsInteractive() {
+		fmt.Printf("Did you mean %s?\n", strings.Join(matches, ", "))
+		return ""
+	}
+	if idx := Choose("Did you mean:", matches); idx > -1 {
+		return matches[idx]
+	}
+	return ""
+}
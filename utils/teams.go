@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/victorgama/go-octokit/octokit"
+)
+
+var (
+	// TeamMembersByRoleURL is the template for members of a team filtered by
+	// their role (member, maintainer or all)
+	TeamMembersByRoleURL = octokit.Hyperlink("teams/{id}/members{?role}")
+
+	// TeamInvitationsURL is the template for pending invitations to join an
+	// organization that include a given team
+	TeamInvitationsURL = octokit.Hyperlink("teams/{id}/invitations")
+)
+
+// TeamParams represents the structure used to create a team. Unlike
+// octokit.TeamParams, it allows teams to be nested and maintainers to be set.
+type TeamParams struct {
+	Name         string   `json:"name,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Privacy      string   `json:"privacy,omitempty"`
+	ParentTeamID int      `json:"parent_team_id,omitempty"`
+	Maintainers  []string `json:"maintainers,omitempty"`
+}
+
+// NestedTeam represents an organization team along with its parent, when the
+// team is nested under another one
+type NestedTeam struct {
+	octokit.Team
+	Parent *octokit.Team `json:"parent,omitempty"`
+}
+
+// GetAllNestedTeamsForOrg returns a list of all teams for a given organization,
+// including information about their parent teams
+func GetAllNestedTeamsForOrg(org string) ([]NestedTeam, error) {
+	result := []NestedTeam{}
+
+	teams := []NestedTeam{}
+	resp := APIRequest("GET", &octokit.OrganizationTeamsURL, octokit.M{"org": org}, nil, &teams)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, teams...)
+		if resp.NextPage != nil {
+			teams = []NestedTeam{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &teams)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ChildTeams returns all teams nested under a given team, at any depth
+func ChildTeams(teams []NestedTeam, parentID int) []NestedTeam {
+	result := []NestedTeam{}
+	for _, t := range teams {
+		if t.Parent != nil && t.Parent.ID == parentID {
+			result = append(result, t)
+			result = append(result, ChildTeams(teams, t.ID)...)
+		}
+	}
+	return result
+}
+
+// IsTeamMember determines whether a given user holds an active membership on
+// a team. Members of child teams are also considered members of their parents.
+func IsTeamMember(teamID int, username string) (bool, error) {
+	client := NewClient()
+	membership, resp := client.Teams().GetMembership(&octokit.TeamMembershipURL, octokit.M{"id": teamID, "username": username})
+	if IsNotFound(resp) {
+		return false, nil
+	}
+	if resp.HasError() {
+		return false, resp.Err
+	}
+	return membership.State == "active", nil
+}
+
+// TeamRepository represents a repository a team has access to, along with the
+// role granted to the team
+type TeamRepository struct {
+	octokit.Repository
+	Permissions *RepoPermissions `json:"permissions,omitempty"`
+	RoleName    string           `json:"role_name,omitempty"`
+}
+
+// Role returns the name of the role granted to the team
+func (r *TeamRepository) Role() string {
+	if r.RoleName != "" {
+		return r.RoleName
+	}
+	return r.Permissions.Highest()
+}
+
+// GetAllReposForTeam returns a list of repositories a given team has access to
+func GetAllReposForTeam(teamID int) ([]TeamRepository, error) {
+	result := []TeamRepository{}
+
+	repos := []TeamRepository{}
+	resp := APIRequest("GET", &octokit.TeamRepositoriesURL, octokit.M{"id": teamID}, nil, &repos)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, repos...)
+		if resp.NextPage != nil {
+			repos = []TeamRepository{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &repos)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetTeamMembersByRole returns a list of members of a given team holding a
+// role. Valid values are member, maintainer and all
+func GetTeamMembersByRole(teamID int, role string) ([]octokit.User, error) {
+	return getAllUsers(&TeamMembersByRoleURL, octokit.M{"id": teamID, "role": role})
+}
+
+// GetChildTeamMemberships returns the slugs of child teams of a given team
+// each user belongs to, keyed by their lowercased logins. Members of child
+// teams are also listed as members of their parents, though only memberships
+// held directly can be removed from the parent.
+func GetChildTeamMemberships(org string, teamID int) (map[string][]string, error) {
+	teams, err := GetAllNestedTeamsForOrg(org)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string][]string{}
+	for _, t := range teams {
+		// Members of child teams already include those of their own children
+		if t.Parent == nil || t.Parent.ID != teamID {
+			continue
+		}
+		members, err := GetTeamMembersByRole(t.ID, "all")
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			key := strings.ToLower(m.Login)
+			result[key] = append(result[key], t.Slug)
+		}
+	}
+	return result, nil
+}
+
+// GetTeamInvitations returns a list of pending organization invitations that
+// will add users to a given team once accepted
+func GetTeamInvitations(teamID int) ([]OrgInvitation, error) {
+	return getAllOrgInvitations(&TeamInvitationsURL, octokit.M{"id": teamID})
+}
@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Capitalize returns a given string with its first letter in upper case
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
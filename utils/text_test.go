@@ -0,0 +1,20 @@
+package utils
+
+import "testing"
+
+func TestCapitalize(t *testing.T) {
+	cases := map[string]string{
+		"":        "",
+		"fix bug": "Fix bug",
+		"Fix bug": "Fix bug",
+		"ébauche": "Ébauche",
+		"ßtraße":  "ßtraße",
+		"1st":     "1st",
+		"\xffbad": "\xffbad",
+	}
+	for in, want := range cases {
+		if got := Capitalize(in); got != want {
+			t.Errorf("Capitalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
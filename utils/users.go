@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"os"
+	"time"
+
+	"github.com/victorgama/go-octokit/octokit"
+	"github.com/victorgama/pine"
+)
+
+var (
+	// UserGPGKeysURL is the template for public GPG keys of a user
+	UserGPGKeysURL = octokit.Hyperlink("users/{user}/gpg_keys")
+)
+
+// GPGKeyEmail represents an email address associated with a GPG key
+type GPGKeyEmail struct {
+	Email    string `json:"email,omitempty"`
+	Verified bool   `json:"verified,omitempty"`
+}
+
+// GPGKey represents a public GPG key of a user
+type GPGKey struct {
+	ID        int           `json:"id,omitempty"`
+	KeyID     string        `json:"key_id,omitempty"`
+	PublicKey string        `json:"public_key,omitempty"`
+	Emails    []GPGKeyEmail `json:"emails,omitempty"`
+	CreatedAt *time.Time    `json:"created_at,omitempty"`
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+}
+
+// GetUser returns the profile of a given user
+func GetUser(username string) (*octokit.User, *octokit.Result) {
+	url, err := octokit.UserURL.Expand(octokit.M{"user": username})
+	if err != nil {
+		return nil, &octokit.Result{Err: err}
+	}
+	return NewClient().Users(url).One()
+}
+
+// FindUser returns the profile of a given user. When the user cannot be
+// found, members of the given organization with similar logins are suggested
+// before exiting; when running interactively, one of them may be picked
+// instead.
+func FindUser(org, username string, logger pine.Writer) *octokit.User {
+	user, resp := GetUser(username)
+	if IsNotFound(resp) {
+		logger.Warn("Could not find a user named @%s", username)
+		members, err := GetAllUsersForOrg(org)
+		if err != nil {
+			os.Exit(1)
+		}
+		picked := ResolveLogin(username, members)
+		if picked == "" {
+			os.Exit(1)
+		}
+		user, resp = GetUser(picked)
+	}
+	HandleClientError(resp, logger)
+	return user
+}
+
+// GetUserOrganizations returns a list of organizations a given user publicly
+// belongs to
+func GetUserOrganizations(username string) ([]octokit.Organization, error) {
+	client := NewClient()
+	result := []octokit.Organization{}
+
+	orgs, resp := client.Organization().UserOrganizations(&octokit.UserOrganizationsURL, octokit.M{"username": username})
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, orgs...)
+		if resp.NextPage != nil {
+			orgs, resp = client.Organization().UserOrganizations(resp.NextPage, nil)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetUserPublicKeys returns a list of public SSH keys of a given user
+func GetUserPublicKeys(username string) ([]octokit.Key, error) {
+	client := NewClient()
+	result := []octokit.Key{}
+
+	keys, resp := client.PublicKeys().All(&octokit.PublicKeyUrl, octokit.M{"user": username})
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, keys...)
+		if resp.NextPage != nil {
+			keys, resp = client.PublicKeys().All(resp.NextPage, nil)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetUserGPGKeys returns a list of public GPG keys of a given user
+func GetUserGPGKeys(username string) ([]GPGKey, error) {
+	result := []GPGKey{}
+
+	keys := []GPGKey{}
+	resp := APIRequest("GET", &UserGPGKeysURL, octokit.M{"user": username}, nil, &keys)
+	for {
+		if resp.HasError() {
+			return nil, resp.Err
+		}
+		result = append(result, keys...)
+		if resp.NextPage != nil {
+			keys = []GPGKey{}
+			resp = APIRequest("GET", resp.NextPage, nil, nil, &keys)
+		} else {
+			break
+		}
+	}
+	return result, nil
+}